@@ -21,13 +21,13 @@ type Ad struct {
 	// Name is the name of the ad
 	Name string `json:"name"`
 	// Status is the status of the ad
-	Status string `json:"status"`
+	Status Status `json:"status"`
 	// ReviewStatus is the status of the ad's review process
-	ReviewStatus string `json:"review_status"`
+	ReviewStatus ReviewStatus `json:"review_status"`
 	// ReviewStatusReason will contain a reason for rejection if an ad was rejected
 	ReviewStatusReason string `json:"review_status_reason"`
 	// Type is the type of the ad
-	Type string `json:"type"`
+	Type AdType `json:"type"`
 	// CreatedAt is the time when the campaign was created
 	CreatedAt time.Time `json:"created_at"`
 	// UpdatedAt is the time when the campaign was last updated
@@ -39,12 +39,29 @@ type GetAdsResponse struct {
 	RequestStatus string        `json:"request_status"`
 	RequestId     string        `json:"request_id"`
 	Ads           []*AdResponse `json:"ads"`
+	Paging        Paging        `json:"paging"`
 }
 
 // AdResponse is the object for a single ad response
 type AdResponse struct {
-	SubRequestStatus string `json:"sub_request_status"`
-	Ad               Ad     `json:"ad"`
+	SubRequestStatus      string `json:"sub_request_status"`
+	SubRequestErrorReason string `json:"sub_request_error_reason"`
+	Ad                    Ad     `json:"ad"`
+}
+
+// adsRequest is the request object used when creating or updating ads
+type adsRequest struct {
+	Ads []*adRequest `json:"ads"`
+}
+
+// adRequest contains the ad fields that can be sent when creating or updating an ad
+type adRequest struct {
+	Id         string `json:"id,omitempty"`
+	AdSquadId  string `json:"ad_squad_id"`
+	CreativeId string `json:"creative_id,omitempty"`
+	Name       string `json:"name,omitempty"`
+	Type       AdType `json:"type,omitempty"`
+	Status     Status `json:"status,omitempty"`
 }
 
 // Get is used to get the specific ad associated with the provided ad id
@@ -61,7 +78,7 @@ func (ad *AdService) Get(ctx context.Context, adId string) (*Ad, error) {
 		return nil, err
 	}
 	if strings.ToLower(a.RequestStatus) == "success" {
-		if len(a.Ads) > 1 {
+		if len(a.Ads) > 0 {
 			return &a.Ads[0].Ad, nil
 		}
 		return nil, fmt.Errorf("no ads found with ad id: %s", adId)
@@ -69,50 +86,82 @@ func (ad *AdService) Get(ctx context.Context, adId string) (*Ad, error) {
 	return nil, fmt.Errorf(`non-success status returned from snapchat api (get ad with id %s): %s`, adId, a.RequestStatus)
 }
 
-// ListByAdSquad will return all of the ads associated with the given ad squad id
-func (ad *AdService) ListByAdSquad(ctx context.Context, adSquadId string) ([]*Ad, error) {
-	path := fmt.Sprintf(`adsquads/%s/ads`, adSquadId)
-	req, err := ad.client.createRequest("GET", path, nil)
+// ListByAdSquad will return all of the ads associated with the given ad squad id, following every page of results
+func (ad *AdService) ListByAdSquad(ctx context.Context, adSquadId string, opts ...func(*ListOptions)) ([]*Ad, error) {
+	var results []*Ad
+	err := collectPages(opts, func(pageOpts []func(*ListOptions)) (string, error) {
+		page, next, err := ad.ListByAdSquadPage(ctx, adSquadId, pageOpts...)
+		results = append(results, page...)
+		return next, err
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	if len(results) > 0 {
+		return results, nil
+	}
+	return nil, fmt.Errorf("no ads found for ad squad id: %s", adSquadId)
+}
+
+// ListByAdSquadPage returns a single page of the ads associated with the given ad squad id, along with
+// the cursor to pass to WithPageCursor to get the next page. The cursor is empty on the last page
+func (ad *AdService) ListByAdSquadPage(ctx context.Context, adSquadId string, opts ...func(*ListOptions)) ([]*Ad, string, error) {
+	path := fmt.Sprintf(`adsquads/%s/ads`, adSquadId)
+	req, err := ad.client.createListRequest(path, opts)
+	if err != nil {
+		return nil, "", err
+	}
+
 	c := new(GetAdsResponse)
 	err = ad.client.do(ctx, req, c)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	if strings.ToLower(c.RequestStatus) == "success" {
-		if len(c.Ads) > 0 {
-			return getAdsFromResponse(c.Ads), nil
-		}
-		return nil, fmt.Errorf("no ads found for ad squad id: %s", adSquadId)
+		return getAdsFromResponse(c.Ads), c.Paging.cursor(), nil
 	}
-	return nil, fmt.Errorf(`non-success status returned from snapchat api (list ads for ad squad with id %s): %s`, adSquadId, c.RequestStatus)
+	return nil, "", fmt.Errorf(`non-success status returned from snapchat api (list ads for ad squad with id %s): %s`, adSquadId, c.RequestStatus)
 }
 
-// ListByAdAccount will return all of the ads associated with the given ad account id
-func (ad *AdService) ListByAdAccount(ctx context.Context, adAccountId string) ([]*Ad, error) {
-	path := fmt.Sprintf(`adaccounts/%s/ads`, adAccountId)
-	req, err := ad.client.createRequest("GET", path, nil)
+// ListByAdAccount will return all of the ads associated with the given ad account id, following every page of results
+func (ad *AdService) ListByAdAccount(ctx context.Context, adAccountId string, opts ...func(*ListOptions)) ([]*Ad, error) {
+	var results []*Ad
+	err := collectPages(opts, func(pageOpts []func(*ListOptions)) (string, error) {
+		page, next, err := ad.ListByAdAccountPage(ctx, adAccountId, pageOpts...)
+		results = append(results, page...)
+		return next, err
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	if len(results) > 0 {
+		return results, nil
+	}
+	return nil, fmt.Errorf("no ads found for ad account id: %s", adAccountId)
+}
+
+// ListByAdAccountPage returns a single page of the ads associated with the given ad account id, along with
+// the cursor to pass to WithPageCursor to get the next page. The cursor is empty on the last page
+func (ad *AdService) ListByAdAccountPage(ctx context.Context, adAccountId string, opts ...func(*ListOptions)) ([]*Ad, string, error) {
+	path := fmt.Sprintf(`adaccounts/%s/ads`, adAccountId)
+	req, err := ad.client.createListRequest(path, opts)
+	if err != nil {
+		return nil, "", err
+	}
+
 	c := new(GetAdsResponse)
 	err = ad.client.do(ctx, req, c)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	if strings.ToLower(c.RequestStatus) == "success" {
-		if len(c.Ads) > 0 {
-			return getAdsFromResponse(c.Ads), nil
-		}
-		return nil, fmt.Errorf("no ads found for ad account id: %s", adAccountId)
+		return getAdsFromResponse(c.Ads), c.Paging.cursor(), nil
 	}
-	return nil, fmt.Errorf(`non-success status returned from snapchat api (list ads for ad account with id %s): %s`, adAccountId, c.RequestStatus)
+	return nil, "", fmt.Errorf(`non-success status returned from snapchat api (list ads for ad account with id %s): %s`, adAccountId, c.RequestStatus)
 }
 
 // Delete deletes a specific ad
@@ -135,6 +184,98 @@ func (ad *AdService) Delete(ctx context.Context, adId string) error {
 	return fmt.Errorf(`non-success status returned from snapchat api (delete ad with id %s): %s`, adId, c.RequestStatus)
 }
 
+// Create creates the provided ads within the specified ad squad and returns them as created by the api,
+// including the ids assigned to them. The id of the provided ads is not sent.
+// If some of the ads could not be created the others are still returned along with an ErrBatchFailure
+func (ad *AdService) Create(ctx context.Context, adSquadId string, ads ...*Ad) ([]*Ad, error) {
+	body := new(adsRequest)
+	for _, a := range ads {
+		r := newAdRequest(adSquadId, a)
+		r.Id = ""
+		body.Ads = append(body.Ads, r)
+	}
+	return ad.save(ctx, "POST", adSquadId, body)
+}
+
+// Update updates the provided ads within the specified ad squad and returns them as updated by the api.
+// Fields that are empty are not sent. If some of the ads could not be updated the others are still returned along with an ErrBatchFailure
+func (ad *AdService) Update(ctx context.Context, adSquadId string, ads ...*Ad) ([]*Ad, error) {
+	body := new(adsRequest)
+	for i, a := range ads {
+		if a.Id == "" {
+			return nil, fmt.Errorf("ad at index %d has no id", i)
+		}
+		body.Ads = append(body.Ads, newAdRequest(adSquadId, a))
+	}
+	return ad.save(ctx, "PUT", adSquadId, body)
+}
+
+// Pause sets the status of a specific ad to PAUSED and returns the updated ad
+func (ad *AdService) Pause(ctx context.Context, adId string) (*Ad, error) {
+	return ad.setStatus(ctx, adId, StatusPaused)
+}
+
+// Activate sets the status of a specific ad to ACTIVE and returns the updated ad
+func (ad *AdService) Activate(ctx context.Context, adId string) (*Ad, error) {
+	return ad.setStatus(ctx, adId, StatusActive)
+}
+
+// setStatus reads an ad, changes its status and writes it back
+func (ad *AdService) setStatus(ctx context.Context, adId string, status Status) (*Ad, error) {
+	a, err := ad.Get(ctx, adId)
+	if err != nil {
+		return nil, err
+	}
+	if a.Status == status {
+		return a, nil
+	}
+
+	a.Status = status
+	ads, err := ad.Update(ctx, a.AdSquadId, a)
+	if err != nil {
+		return nil, err
+	}
+	if len(ads) > 0 {
+		return ads[0], nil
+	}
+	return nil, fmt.Errorf("no ads returned when updating ad with id: %s", adId)
+}
+
+// save sends a create or update request for ads within an ad squad
+func (ad *AdService) save(ctx context.Context, method, adSquadId string, body *adsRequest) ([]*Ad, error) {
+	if len(body.Ads) == 0 {
+		return nil, fmt.Errorf("no ads provided")
+	}
+
+	path := fmt.Sprintf(`adsquads/%s/ads`, adSquadId)
+	req, err := ad.client.createRequest(method, path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	c := new(GetAdsResponse)
+	err = ad.client.do(ctx, req, c)
+	if len(c.Ads) > 0 {
+		return getAdsFromBatchResponse(c.Ads, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf(`non-success status returned from snapchat api (save ads for ad squad with id %s): %s`, adSquadId, c.RequestStatus)
+}
+
+// newAdRequest copies the writable fields of an ad into a request object
+func newAdRequest(adSquadId string, a *Ad) *adRequest {
+	return &adRequest{
+		Id:         a.Id,
+		AdSquadId:  adSquadId,
+		CreativeId: a.CreativeId,
+		Name:       a.Name,
+		Type:       a.Type,
+		Status:     a.Status,
+	}
+}
+
 func getAdsFromResponse(list []*AdResponse) []*Ad {
 	var results []*Ad
 	for _, val := range list {
@@ -144,3 +285,18 @@ func getAdsFromResponse(list []*AdResponse) []*Ad {
 	}
 	return results
 }
+
+// getAdsFromBatchResponse returns the successful ads in a create or update response, along with
+// an ErrBatchFailure describing any ads that were not successful and wrapping requestErr, the error of the request
+func getAdsFromBatchResponse(list []*AdResponse, requestErr error) ([]*Ad, error) {
+	var results []*Ad
+	batchErr := new(ErrBatchFailure)
+	for i, val := range list {
+		if strings.ToLower(val.SubRequestStatus) == "success" {
+			results = append(results, &val.Ad)
+			continue
+		}
+		batchErr.add(i, val.SubRequestStatus, val.SubRequestErrorReason)
+	}
+	return results, batchErr.wrap(requestErr)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 )
 
 // AdAccountService provides functions for interacting with snapchat ad accounts
@@ -22,7 +23,7 @@ type AdAccount struct {
 	// Type is the type of ad account
 	Type string `json:"type"`
 	// LifetimeSpendCapMicro is the lifetime spend limit for the account
-	LifetimeSpendCapMicro int64 `json:"lifetime_spend_cap_micro"`
+	LifetimeSpendCapMicro Micro `json:"lifetime_spend_cap_micro"`
 	// AdvertiserOrganizationId is the organization id of the advertiser selected
 	AdvertiserOrganizationId string `json:"advertiser_organization_id"`
 	// Advertiser is the name of the advertiser associated with the account
@@ -33,6 +34,16 @@ type AdAccount struct {
 	FundingSourceIds []string `json:"funding_source_ids"`
 }
 
+// Location returns the location of the ad account's timezone, which snapchat uses to report stats
+func (account *AdAccount) Location() (*time.Location, error) {
+	return time.LoadLocation(account.Timezone)
+}
+
+// FormatMicro formats an amount of micro-currency in the ad account's currency, e.g. "$12.35"
+func (account *AdAccount) FormatMicro(amount Micro) string {
+	return amount.Format(account.Currency)
+}
+
 // GetAdAccountsResponse is the response object for calls to get ad accounts
 type GetAdAccountsResponse struct {
 	// RequestStatus is the status of the get ad account request
@@ -41,6 +52,8 @@ type GetAdAccountsResponse struct {
 	RequestId string `json:"request_id"`
 	// AdAccounts is a list of individual ad account responses
 	AdAccounts []*AdAccountResponse `json:"adaccounts"`
+	// Paging contains the link to the next page of results
+	Paging Paging `json:"paging"`
 }
 
 // AdAccountResponse is the individual organization object in the response for calls to get ad accounts
@@ -65,7 +78,7 @@ func (ad *AdAccountService) Get(ctx context.Context, adAccountId string) (*AdAcc
 		return nil, err
 	}
 	if strings.ToLower(a.RequestStatus) == "success" {
-		if len(a.AdAccounts) > 1 {
+		if len(a.AdAccounts) > 0 {
 			return &a.AdAccounts[0].AdAccount, nil
 		}
 		return nil, fmt.Errorf("no ad accounts found with ad account id: %s", adAccountId)
@@ -73,27 +86,43 @@ func (ad *AdAccountService) Get(ctx context.Context, adAccountId string) (*AdAcc
 	return nil, fmt.Errorf(`non-success status returned from snapchat api (get ad account): %s`, a.RequestStatus)
 }
 
-// List returns all ad accounts associated with the provided organization id
-func (ad *AdAccountService) List(ctx context.Context, organizationId string) ([]*AdAccount, error) {
-	path := fmt.Sprintf(`organizations/%s/adaccounts`, organizationId)
-	req, err := ad.client.createRequest("GET", path, nil)
+// List returns all ad accounts associated with the provided organization id, following every page of results
+func (ad *AdAccountService) List(ctx context.Context, organizationId string, opts ...func(*ListOptions)) ([]*AdAccount, error) {
+	var results []*AdAccount
+	err := collectPages(opts, func(pageOpts []func(*ListOptions)) (string, error) {
+		page, next, err := ad.ListPage(ctx, organizationId, pageOpts...)
+		results = append(results, page...)
+		return next, err
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	if len(results) > 0 {
+		return results, nil
+	}
+	return nil, fmt.Errorf("no ad accounts found for organization id: %s", organizationId)
+}
+
+// ListPage returns a single page of the ad accounts associated with the provided organization id, along with
+// the cursor to pass to WithPageCursor to get the next page. The cursor is empty on the last page
+func (ad *AdAccountService) ListPage(ctx context.Context, organizationId string, opts ...func(*ListOptions)) ([]*AdAccount, string, error) {
+	path := fmt.Sprintf(`organizations/%s/adaccounts`, organizationId)
+	req, err := ad.client.createListRequest(path, opts)
+	if err != nil {
+		return nil, "", err
+	}
+
 	c := new(GetAdAccountsResponse)
 	err = ad.client.do(ctx, req, c)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	if strings.ToLower(c.RequestStatus) == "success" {
-		if len(c.AdAccounts) > 0 {
-			return getAdAccountsFromResponse(c.AdAccounts), nil
-		}
-		return nil, fmt.Errorf("no ad accounts found for organization id: %s", organizationId)
+		return getAdAccountsFromResponse(c.AdAccounts), c.Paging.cursor(), nil
 	}
-	return nil, fmt.Errorf(`non-success status returned from snapchat api (list ad accounts): %s`, c.RequestStatus)
+	return nil, "", fmt.Errorf(`non-success status returned from snapchat api (list ad accounts): %s`, c.RequestStatus)
 }
 
 // getAdAccountsFromResponse returns the organization objects in an GetAdAccountsResponse object
@@ -17,11 +17,11 @@ type AdSquad struct {
 	// CampaignId is the id of the campaign this ad squad is associated with
 	CampaignId string `json:"campaign_id"`
 	// BidMicro the max bid (micro-currency)
-	BidMicro int64 `json:"bid_micro"`
+	BidMicro Micro `json:"bid_micro"`
 	// BillingEvent is the billing event associated with the ad squad
-	BillingEvent string `json:"billing_event"`
+	BillingEvent BillingEvent `json:"billing_event"`
 	// DailyBudgetMicro is the daily spend budget (micro-currency)
-	DailyBudgetMicro int64 `json:"daily_budget_micro"`
+	DailyBudgetMicro Micro `json:"daily_budget_micro"`
 	// EndTime is the ending time of the ad squad
 	EndTime time.Time `json:"end_time"`
 	// StartTime is the starting time of the ad squad
@@ -29,25 +29,64 @@ type AdSquad struct {
 	// Name is the name of the ad squad
 	Name string `json:"name"`
 	// OptimizationGoal is the optimization goal of the ad squad
-	OptimizationGoal string `json:"optimization_goal"`
+	OptimizationGoal OptimizationGoal `json:"optimization_goal"`
 	// Placement is the placement for the ad squad
-	Placement string `json:"placement"`
+	Placement Placement `json:"placement"`
 	// Status is the status of the ad squad
-	Status string `json:"status"`
+	Status Status `json:"status"`
 	// Targeting is the targeting spec of the ad squad
-	//Targeting string `json:"targeting"` // might not be a string
+	Targeting *Targeting `json:"targeting"`
 	// IncludedContentType is a list of content types that will be included in this ad squad
 	IncludedContentType []string `json:"included_content_types"`
 	// ExcludedContentType is a list of content types that will be excluded in this ad squad
 	ExcludedContentType []string `json:"excluded_content_types"`
 	// CapAndExclusionConfig is the frequency cap and exclusion spec
-	//CapAndExclusionConfig string `json:"cap_and_exclusion_config"` // might not be a string
+	CapAndExclusionConfig *CapAndExclusionConfig `json:"cap_and_exclusion_config"`
 	// LifetimeBudgetMicro is the lifetime spend budget of the ad squad (micro-currency)
-	LifetimeBudgetMicro int64 `json:"lifetime_budget_micro"`
+	LifetimeBudgetMicro Micro `json:"lifetime_budget_micro"`
 	// AdSchedulingConfig is the schedule for running ads on this adsquad
-	//AdSchedulingConfig string `json:"ad_scheduling_config"` // might not be a string
+	AdSchedulingConfig *AdSchedulingConfig `json:"ad_scheduling_config"`
 	// Type is the type of ad squad
 	Type string `json:"type"`
+	// ClearFields lists optional fields by json name, such as "bid_micro", that are sent even if they are zero,
+	// e.g. to remove the daily budget with Update. Optional fields are not sent while they are zero otherwise
+	ClearFields []string `json:"-"`
+}
+
+// CapAndExclusionConfig is the frequency cap and exclusion spec of an ad squad
+type CapAndExclusionConfig struct {
+	// FrequencyCapConfig is the list of frequency caps applied to the ad squad
+	FrequencyCapConfig []*FrequencyCap `json:"frequency_cap_config,omitempty"`
+}
+
+// FrequencyCap limits how many times a user is shown the ads of an ad squad within a time interval
+type FrequencyCap struct {
+	// FrequencyCapCount is the maximum number of events per user within the time interval
+	FrequencyCapCount int `json:"frequency_cap_count"`
+	// FrequencyCapType is the type of event being capped (IMPRESSIONS)
+	FrequencyCapType string `json:"frequency_cap_type"`
+	// TimeInterval is the length of the time interval, in units of FrequencyCapInterval
+	TimeInterval int `json:"time_interval"`
+	// FrequencyCapInterval is the unit of the time interval (HOURS, DAYS)
+	FrequencyCapInterval string `json:"frequency_cap_interval"`
+}
+
+// AdSchedulingConfig is the day-parting schedule of an ad squad. Ads are only delivered during the
+// listed hours of the listed days, days that are nil are not scheduled
+type AdSchedulingConfig struct {
+	Monday    *DaySchedule `json:"MONDAY,omitempty"`
+	Tuesday   *DaySchedule `json:"TUESDAY,omitempty"`
+	Wednesday *DaySchedule `json:"WEDNESDAY,omitempty"`
+	Thursday  *DaySchedule `json:"THURSDAY,omitempty"`
+	Friday    *DaySchedule `json:"FRIDAY,omitempty"`
+	Saturday  *DaySchedule `json:"SATURDAY,omitempty"`
+	Sunday    *DaySchedule `json:"SUNDAY,omitempty"`
+}
+
+// DaySchedule lists the hours of a day during which ads are delivered
+type DaySchedule struct {
+	// HourOfDay is a list of hours of the day (0 - 23) in the ad account's timezone
+	HourOfDay []int `json:"hour_of_day"`
 }
 
 // GetAdSquadsResponse is the response object returned when getting ad squads
@@ -55,12 +94,41 @@ type GetAdSquadsResponse struct {
 	RequestStatus string             `json:"request_status"`
 	RequestId     string             `json:"request_id"`
 	AdSquads      []*AdSquadResponse `json:"adsquads"`
+	Paging        Paging             `json:"paging"`
 }
 
 // AdSquadResponse is the object for a single ad squad response
 type AdSquadResponse struct {
-	SubRequestStatus string  `json:"sub_request_status"`
-	AdSquad          AdSquad `json:"adsquad"`
+	SubRequestStatus      string  `json:"sub_request_status"`
+	SubRequestErrorReason string  `json:"sub_request_error_reason"`
+	AdSquad               AdSquad `json:"adsquad"`
+}
+
+// adSquadsRequest is the request object used when creating or updating ad squads
+type adSquadsRequest struct {
+	AdSquads []*adSquadRequest `json:"adsquads"`
+}
+
+// adSquadRequest contains the ad squad fields that can be sent when creating or updating an ad squad
+type adSquadRequest struct {
+	Id                    string                 `json:"id,omitempty"`
+	CampaignId            string                 `json:"campaign_id"`
+	Name                  string                 `json:"name,omitempty"`
+	Type                  string                 `json:"type,omitempty"`
+	Status                Status                 `json:"status,omitempty"`
+	Placement             Placement              `json:"placement,omitempty"`
+	OptimizationGoal      OptimizationGoal       `json:"optimization_goal,omitempty"`
+	BillingEvent          BillingEvent           `json:"billing_event,omitempty"`
+	BidMicro              *Micro                 `json:"bid_micro,omitempty"`
+	DailyBudgetMicro      *Micro                 `json:"daily_budget_micro,omitempty"`
+	LifetimeBudgetMicro   *Micro                 `json:"lifetime_budget_micro,omitempty"`
+	StartTime             *time.Time             `json:"start_time,omitempty"`
+	EndTime               *time.Time             `json:"end_time,omitempty"`
+	Targeting             *Targeting             `json:"targeting,omitempty"`
+	IncludedContentType   []string               `json:"included_content_types,omitempty"`
+	ExcludedContentType   []string               `json:"excluded_content_types,omitempty"`
+	CapAndExclusionConfig *CapAndExclusionConfig `json:"cap_and_exclusion_config,omitempty"`
+	AdSchedulingConfig    *AdSchedulingConfig    `json:"ad_scheduling_config,omitempty"`
 }
 
 // Get retrieves a specific ad squad
@@ -85,50 +153,82 @@ func (adsqd *AdSquadService) Get(ctx context.Context, adSquadId string) (*AdSqua
 	return nil, fmt.Errorf(`non-success status returned from snapchat api (get ad squad with id %s): %s`, adSquadId, a.RequestStatus)
 }
 
-// ListByCampaign retrieves all ad squads associated a specified campaign id
-func (adsqd *AdSquadService) ListByCampaign(ctx context.Context, campaignId string) ([]*AdSquad, error) {
-	path := fmt.Sprintf(`campaigns/%s/adsquads`, campaignId)
-	req, err := adsqd.client.createRequest("GET", path, nil)
+// ListByCampaign retrieves all ad squads associated a specified campaign id, following every page of results
+func (adsqd *AdSquadService) ListByCampaign(ctx context.Context, campaignId string, opts ...func(*ListOptions)) ([]*AdSquad, error) {
+	var results []*AdSquad
+	err := collectPages(opts, func(pageOpts []func(*ListOptions)) (string, error) {
+		page, next, err := adsqd.ListByCampaignPage(ctx, campaignId, pageOpts...)
+		results = append(results, page...)
+		return next, err
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	if len(results) > 0 {
+		return results, nil
+	}
+	return nil, fmt.Errorf("no ad squads found for campaign id: %s", campaignId)
+}
+
+// ListByCampaignPage returns a single page of the ad squads associated with a specified campaign id, along with
+// the cursor to pass to WithPageCursor to get the next page. The cursor is empty on the last page
+func (adsqd *AdSquadService) ListByCampaignPage(ctx context.Context, campaignId string, opts ...func(*ListOptions)) ([]*AdSquad, string, error) {
+	path := fmt.Sprintf(`campaigns/%s/adsquads`, campaignId)
+	req, err := adsqd.client.createListRequest(path, opts)
+	if err != nil {
+		return nil, "", err
+	}
+
 	c := new(GetAdSquadsResponse)
 	err = adsqd.client.do(ctx, req, c)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	if strings.ToLower(c.RequestStatus) == "success" {
-		if len(c.AdSquads) > 0 {
-			return getAdSquadsFromResponse(c.AdSquads), nil
-		}
-		return nil, fmt.Errorf("no ad squads found for campaign id: %s", campaignId)
+		return getAdSquadsFromResponse(c.AdSquads), c.Paging.cursor(), nil
 	}
-	return nil, fmt.Errorf(`non-success status returned from snapchat api (list ad squads for campaign with id %s): %s`, campaignId, c.RequestStatus)
+	return nil, "", fmt.Errorf(`non-success status returned from snapchat api (list ad squads for campaign with id %s): %s`, campaignId, c.RequestStatus)
 }
 
-// ListByAdAccount retrieves all ad squads associated a specified ad account id
-func (adsqd *AdSquadService) ListByAdAccount(ctx context.Context, adAccountId string) ([]*AdSquad, error) {
-	path := fmt.Sprintf(`adaccounts/%s/adsquads`, adAccountId)
-	req, err := adsqd.client.createRequest("GET", path, nil)
+// ListByAdAccount retrieves all ad squads associated a specified ad account id, following every page of results
+func (adsqd *AdSquadService) ListByAdAccount(ctx context.Context, adAccountId string, opts ...func(*ListOptions)) ([]*AdSquad, error) {
+	var results []*AdSquad
+	err := collectPages(opts, func(pageOpts []func(*ListOptions)) (string, error) {
+		page, next, err := adsqd.ListByAdAccountPage(ctx, adAccountId, pageOpts...)
+		results = append(results, page...)
+		return next, err
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	if len(results) > 0 {
+		return results, nil
+	}
+	return nil, fmt.Errorf("no ad squads found for ad account id: %s", adAccountId)
+}
+
+// ListByAdAccountPage returns a single page of the ad squads associated with a specified ad account id, along with
+// the cursor to pass to WithPageCursor to get the next page. The cursor is empty on the last page
+func (adsqd *AdSquadService) ListByAdAccountPage(ctx context.Context, adAccountId string, opts ...func(*ListOptions)) ([]*AdSquad, string, error) {
+	path := fmt.Sprintf(`adaccounts/%s/adsquads`, adAccountId)
+	req, err := adsqd.client.createListRequest(path, opts)
+	if err != nil {
+		return nil, "", err
+	}
+
 	c := new(GetAdSquadsResponse)
 	err = adsqd.client.do(ctx, req, c)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	if strings.ToLower(c.RequestStatus) == "success" {
-		if len(c.AdSquads) > 0 {
-			return getAdSquadsFromResponse(c.AdSquads), nil
-		}
-		return nil, fmt.Errorf("no ad squads found for ad account id: %s", adAccountId)
+		return getAdSquadsFromResponse(c.AdSquads), c.Paging.cursor(), nil
 	}
-	return nil, fmt.Errorf(`non-success status returned from snapchat api (list ad squads for ad account with id %s): %s`, adAccountId, c.RequestStatus)
+	return nil, "", fmt.Errorf(`non-success status returned from snapchat api (list ad squads for ad account with id %s): %s`, adAccountId, c.RequestStatus)
 }
 
 // Delete deletes a specific ad squad
@@ -151,6 +251,90 @@ func (adsqd *AdSquadService) Delete(ctx context.Context, adSquadId string) error
 	return fmt.Errorf(`non-success status returned from snapchat api (delete ad squad with id %s): %s`, adSquadId, c.RequestStatus)
 }
 
+// Create creates the provided ad squads within the specified campaign and returns them as created by the api,
+// including the ids assigned to them. The id of the provided ad squads is not sent.
+// If some of the ad squads could not be created the others are still returned along with an ErrBatchFailure
+func (adsqd *AdSquadService) Create(ctx context.Context, campaignId string, adSquads ...*AdSquad) ([]*AdSquad, error) {
+	body := new(adSquadsRequest)
+	for i, adSquad := range adSquads {
+		a, err := newAdSquadRequest(campaignId, adSquad)
+		if err != nil {
+			return nil, fmt.Errorf("ad squad at index %d: %w", i, err)
+		}
+		a.Id = ""
+		body.AdSquads = append(body.AdSquads, a)
+	}
+	return adsqd.save(ctx, "POST", campaignId, body)
+}
+
+// Update updates the provided ad squads within the specified campaign and returns them as updated by the api.
+// Fields that are empty or zero are not sent, use ClearFields to set an optional field to zero. If some of the ad squads could not be updated the others are still returned along with an ErrBatchFailure
+func (adsqd *AdSquadService) Update(ctx context.Context, campaignId string, adSquads ...*AdSquad) ([]*AdSquad, error) {
+	body := new(adSquadsRequest)
+	for i, adSquad := range adSquads {
+		if adSquad.Id == "" {
+			return nil, fmt.Errorf("ad squad at index %d has no id", i)
+		}
+		a, err := newAdSquadRequest(campaignId, adSquad)
+		if err != nil {
+			return nil, fmt.Errorf("ad squad at index %d: %w", i, err)
+		}
+		body.AdSquads = append(body.AdSquads, a)
+	}
+	return adsqd.save(ctx, "PUT", campaignId, body)
+}
+
+// save sends a create or update request for ad squads within a campaign
+func (adsqd *AdSquadService) save(ctx context.Context, method, campaignId string, body *adSquadsRequest) ([]*AdSquad, error) {
+	if len(body.AdSquads) == 0 {
+		return nil, fmt.Errorf("no ad squads provided")
+	}
+
+	path := fmt.Sprintf(`campaigns/%s/adsquads`, campaignId)
+	req, err := adsqd.client.createRequest(method, path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	c := new(GetAdSquadsResponse)
+	err = adsqd.client.do(ctx, req, c)
+	if len(c.AdSquads) > 0 {
+		return getAdSquadsFromBatchResponse(c.AdSquads, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf(`non-success status returned from snapchat api (save ad squads for campaign with id %s): %s`, campaignId, c.RequestStatus)
+}
+
+// newAdSquadRequest copies the writable fields of an ad squad into a request object
+func newAdSquadRequest(campaignId string, adSquad *AdSquad) (*adSquadRequest, error) {
+	cleared, err := clearedFields(adSquad.ClearFields, "bid_micro", "daily_budget_micro", "lifetime_budget_micro")
+	if err != nil {
+		return nil, err
+	}
+	return &adSquadRequest{
+		Id:                    adSquad.Id,
+		CampaignId:            campaignId,
+		Name:                  adSquad.Name,
+		Type:                  adSquad.Type,
+		Status:                adSquad.Status,
+		Placement:             adSquad.Placement,
+		OptimizationGoal:      adSquad.OptimizationGoal,
+		BillingEvent:          adSquad.BillingEvent,
+		BidMicro:              optionalValue(adSquad.BidMicro, cleared["bid_micro"]),
+		DailyBudgetMicro:      optionalValue(adSquad.DailyBudgetMicro, cleared["daily_budget_micro"]),
+		LifetimeBudgetMicro:   optionalValue(adSquad.LifetimeBudgetMicro, cleared["lifetime_budget_micro"]),
+		StartTime:             optionalTime(adSquad.StartTime),
+		EndTime:               optionalTime(adSquad.EndTime),
+		Targeting:             adSquad.Targeting,
+		IncludedContentType:   adSquad.IncludedContentType,
+		ExcludedContentType:   adSquad.ExcludedContentType,
+		CapAndExclusionConfig: adSquad.CapAndExclusionConfig,
+		AdSchedulingConfig:    adSquad.AdSchedulingConfig,
+	}, nil
+}
+
 func getAdSquadsFromResponse(list []*AdSquadResponse) []*AdSquad {
 	var results []*AdSquad
 	for _, val := range list {
@@ -160,3 +344,18 @@ func getAdSquadsFromResponse(list []*AdSquadResponse) []*AdSquad {
 	}
 	return results
 }
+
+// getAdSquadsFromBatchResponse returns the successful ad squads in a create or update response, along with
+// an ErrBatchFailure describing any ad squads that were not successful and wrapping requestErr, the error of the request
+func getAdSquadsFromBatchResponse(list []*AdSquadResponse, requestErr error) ([]*AdSquad, error) {
+	var results []*AdSquad
+	batchErr := new(ErrBatchFailure)
+	for i, val := range list {
+		if strings.ToLower(val.SubRequestStatus) == "success" {
+			results = append(results, &val.AdSquad)
+			continue
+		}
+		batchErr.add(i, val.SubRequestStatus, val.SubRequestErrorReason)
+	}
+	return results, batchErr.wrap(requestErr)
+}
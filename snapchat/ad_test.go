@@ -0,0 +1,60 @@
+package snapchat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newBatchTestClient returns a client for a server that answers every request with the status code and body
+func newBatchTestClient(t *testing.T, statusCode int, body string) *Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(statusCode)
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(server.Close)
+	cli, err := NewClient(WithHost(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cli
+}
+
+func TestAdsCreateReportsFailuresOfFailedBatch(t *testing.T) {
+	cli := newBatchTestClient(t, 400, `{"request_status":"ERROR","request_id":"req","ads":[`+
+		`{"sub_request_status":"SUCCESS","ad":{"id":"created"}},`+
+		`{"sub_request_status":"ERROR","sub_request_error_reason":"bad name"}]}`)
+
+	ads, err := cli.Ads.Create(context.Background(), "adsquad", &Ad{Name: "good"}, &Ad{Name: "bad"})
+	if len(ads) != 1 || ads[0].Id != "created" {
+		t.Errorf("got ads %v, want the created ad", ads)
+	}
+	var batchErr *ErrBatchFailure
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("got error %v, want an ErrBatchFailure", err)
+	}
+	if len(batchErr.Failures) != 1 || batchErr.Failures[0].Index != 1 || batchErr.Failures[0].Reason != "bad name" {
+		t.Errorf("got failures %+v, want the second ad to fail", batchErr.Failures)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.RequestId != "req" || !errors.Is(err, new(ErrBadRequest)) {
+		t.Errorf("got error %v, want it to wrap the api error", err)
+	}
+}
+
+func TestAdsCreateReturnsAPIErrorWithoutBatch(t *testing.T) {
+	cli := newBatchTestClient(t, 403, `{"request_status":"ERROR","debug_message":"no access"}`)
+
+	ads, err := cli.Ads.Create(context.Background(), "adsquad", &Ad{Name: "ad"})
+	if ads != nil {
+		t.Errorf("got ads %v, want none", ads)
+	}
+	var batchErr *ErrBatchFailure
+	if errors.As(err, &batchErr) || !errors.Is(err, new(ErrForbidden)) {
+		t.Errorf("got error %v, want only the api error", err)
+	}
+}
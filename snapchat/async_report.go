@@ -0,0 +1,214 @@
+package snapchat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context/ctxhttp"
+	"golang.org/x/oauth2"
+)
+
+// AsyncReportStatus is the status of an async stats report
+type AsyncReportStatus string
+
+const (
+	// AsyncReportStarted is the status of a report that was submitted but is not being built yet
+	AsyncReportStarted AsyncReportStatus = "STARTED"
+	// AsyncReportRunning is the status of a report that is being built
+	AsyncReportRunning AsyncReportStatus = "RUNNING"
+	// AsyncReportCompleted is the status of a report whose result is ready to download
+	AsyncReportCompleted AsyncReportStatus = "COMPLETED"
+	// AsyncReportFailed is the status of a report that could not be built
+	AsyncReportFailed AsyncReportStatus = "FAILED"
+)
+
+// reportPollPolicy is the backoff used between polls of an async report's status
+var reportPollPolicy = RetryPolicy{
+	BaseDelay: time.Second,
+	MaxDelay:  30 * time.Second,
+}
+
+// AsyncReport is a stats report that is built by the api in the background, for stats that would take too
+// long to return synchronously
+type AsyncReport struct {
+	// ReportRunId is the id of the report
+	ReportRunId string `json:"report_run_id"`
+	// AsyncStatus is the status of the report
+	AsyncStatus AsyncReportStatus `json:"async_status"`
+	// Result is the url to download the report from, only set once the report is COMPLETED
+	Result string `json:"result"`
+	// CreatedAt is the time when the report was submitted
+	CreatedAt time.Time `json:"created_at"`
+	// CompletedAt is the time when the report was completed
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+// GetAsyncReportsResponse is the response object returned when submitting or getting async reports
+type GetAsyncReportsResponse struct {
+	RequestStatus     string                 `json:"request_status"`
+	RequestId         string                 `json:"request_id"`
+	AsyncStatsReports []*AsyncReportResponse `json:"async_stats_reports"`
+}
+
+// AsyncReportResponse is the object for a single async report response
+type AsyncReportResponse struct {
+	SubRequestStatus string      `json:"sub_request_status"`
+	AsyncStatsReport AsyncReport `json:"async_stats_report"`
+}
+
+// ReportProgress describes the state of an async report while waiting for it to complete
+type ReportProgress struct {
+	// ReportRunId is the id of the report
+	ReportRunId string
+	// Status is the status of the report as of the latest poll
+	Status AsyncReportStatus
+	// Polls is the number of times the status of the report has been requested
+	Polls int
+	// Elapsed is the time spent waiting for the report so far
+	Elapsed time.Duration
+}
+
+// RunReport submits an async stats report for the given ad account, waits for it to complete and downloads
+// its result. progress, if not nil, is called after every poll of the report's status. The stats options
+// are the same as for GetStatsForAdAccount, use WithBreakdown to get the stats of every campaign, ad squad or ad
+func (measurement *MeasurementService) RunReport(ctx context.Context, adAccountId string, progress func(ReportProgress), opts ...func(*StatsOptions)) (*GetMeasurementsResponse, error) {
+	report, err := measurement.SubmitReport(ctx, adAccountId, opts...)
+	if err != nil {
+		return nil, err
+	}
+	report, err = measurement.WaitForReport(ctx, adAccountId, report.ReportRunId, progress)
+	if err != nil {
+		return nil, err
+	}
+	return measurement.DownloadReport(ctx, report)
+}
+
+// SubmitReport submits an async stats report for the given ad account and returns it without waiting for it
+// to complete, see WaitForReport. The request is not retried, since sending it again would submit another report
+func (measurement *MeasurementService) SubmitReport(ctx context.Context, adAccountId string, opts ...func(*StatsOptions)) (*AsyncReport, error) {
+	params, err := statsParams(opts)
+	if err != nil {
+		return nil, err
+	}
+	params.Set("async", "true")
+	params.Set("async_format", "json")
+
+	path := fmt.Sprintf(`adaccounts/%s/stats?%s`, adAccountId, params.Encode())
+	return measurement.getReport(ctx, path, fmt.Sprintf(`submit report for ad account with id %s`, adAccountId), false)
+}
+
+// GetReport retrieves the current state of a specific async report
+func (measurement *MeasurementService) GetReport(ctx context.Context, adAccountId, reportRunId string) (*AsyncReport, error) {
+	params := url.Values{}
+	params.Set("report_run_id", reportRunId)
+	path := fmt.Sprintf(`adaccounts/%s/stats_report?%s`, adAccountId, params.Encode())
+	return measurement.getReport(ctx, path, fmt.Sprintf(`get report with id %s`, reportRunId), true)
+}
+
+// WaitForReport polls a specific async report, backing off between polls, until it is COMPLETED and returns it.
+// progress, if not nil, is called after every poll. It returns an error if the report FAILED, or once the
+// context is done, along with the report as of the latest poll
+func (measurement *MeasurementService) WaitForReport(ctx context.Context, adAccountId, reportRunId string, progress func(ReportProgress)) (*AsyncReport, error) {
+	start := time.Now()
+	for poll := 1; ; poll++ {
+		report, err := measurement.GetReport(ctx, adAccountId, reportRunId)
+		if err != nil {
+			return nil, err
+		}
+		if progress != nil {
+			progress(ReportProgress{
+				ReportRunId: reportRunId,
+				Status:      report.AsyncStatus,
+				Polls:       poll,
+				Elapsed:     time.Since(start),
+			})
+		}
+		switch report.AsyncStatus {
+		case AsyncReportCompleted:
+			return report, nil
+		case AsyncReportFailed:
+			return report, fmt.Errorf("async report with id %s failed", reportRunId)
+		}
+		if err := sleep(ctx, reportPollPolicy.delay(poll, nil)); err != nil {
+			return report, err
+		}
+	}
+}
+
+// DownloadReport downloads the result of a completed async report and decodes it into the stats model.
+// The result is hosted outside the ads api, so it is downloaded without the client's credentials, but with the
+// transport and timeout of the http client configured with WithHTTPClient
+func (measurement *MeasurementService) DownloadReport(ctx context.Context, report *AsyncReport) (*GetMeasurementsResponse, error) {
+	if report.AsyncStatus != AsyncReportCompleted || report.Result == "" {
+		return nil, fmt.Errorf("async report with id %s is not completed: %s", report.ReportRunId, report.AsyncStatus)
+	}
+	req, err := http.NewRequest("GET", report.Result, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := ctxhttp.Do(ctx, measurement.client.unauthorizedHTTPClient(), req)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 400 {
+		return nil, newAPIError(response, nil)
+	}
+
+	m := new(GetMeasurementsResponse)
+	if err := json.NewDecoder(response.Body).Decode(m); err != nil {
+		return nil, fmt.Errorf("decode async report with id %s: %w", report.ReportRunId, err)
+	}
+	if m.RequestStatus == "" || strings.ToLower(m.RequestStatus) == "success" {
+		return m, nil
+	}
+	return nil, fmt.Errorf(`non-success status returned from snapchat api (download report with id %s): %s`, report.ReportRunId, m.RequestStatus)
+}
+
+// unauthorizedHTTPClient returns an http client with the transport, redirect policy, cookies and timeout of the
+// configured http client, minus the oauth2 transport that adds the client's credentials to every request
+func (cli *Client) unauthorizedHTTPClient() *http.Client {
+	configured := cli.httpClient()
+	client := *configured
+	if transport, ok := configured.Transport.(*oauth2.Transport); ok {
+		client.Transport = transport.Base
+	}
+	if client.Timeout == 0 {
+		client.Timeout = time.Minute
+	}
+	return &client
+}
+
+// getReport retrieves the first async report returned from path, description is used to describe the request in errors.
+// The request is only retried according to the client's retry policy if retry is true
+func (measurement *MeasurementService) getReport(ctx context.Context, path, description string, retry bool) (*AsyncReport, error) {
+	req, err := measurement.client.createRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	r := new(GetAsyncReportsResponse)
+	if retry {
+		err = measurement.client.do(ctx, req, r)
+	} else {
+		err = measurement.client.doOnce(ctx, req, r)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if strings.ToLower(r.RequestStatus) == "success" {
+		for _, val := range r.AsyncStatsReports {
+			if strings.ToLower(val.SubRequestStatus) == "success" {
+				return &val.AsyncStatsReport, nil
+			}
+		}
+		return nil, fmt.Errorf("no async report returned (%s)", description)
+	}
+	return nil, fmt.Errorf(`non-success status returned from snapchat api (%s): %s`, description, r.RequestStatus)
+}
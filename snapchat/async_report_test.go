@@ -0,0 +1,31 @@
+package snapchat
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSubmitReportIsNotRetried(t *testing.T) {
+	var submits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&submits, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	cli, err := NewClient(WithHost(server.URL), WithRetryPolicy(RetryPolicy{MaxAttempts: 3}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = cli.Measurements.SubmitReport(context.Background(), "account")
+	if !errors.Is(err, new(ErrServiceUnavailable)) {
+		t.Errorf("got error %v, want the api error", err)
+	}
+	if submits != 1 {
+		t.Errorf("sent %d submit requests, want 1", submits)
+	}
+}
@@ -0,0 +1,334 @@
+package snapchat
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	// SnapchatAuthURL is the url advertisers are sent to in order to grant access to their snapchat ads accounts
+	SnapchatAuthURL = `https://accounts.snapchat.com/login/oauth2/authorize`
+	// SnapchatTokenURL is the url used to exchange authorization codes and refresh tokens for access tokens
+	SnapchatTokenURL = `https://accounts.snapchat.com/login/oauth2/access_token`
+)
+
+// Endpoint is the oauth2 endpoint of snapchat
+var Endpoint = oauth2.Endpoint{
+	AuthURL:   SnapchatAuthURL,
+	TokenURL:  SnapchatTokenURL,
+	AuthStyle: oauth2.AuthStyleInParams,
+}
+
+// WithOAuth2Config allows the user to authorize with an oauth2 app and a refresh token. Access tokens are
+// requested from snapchat's token endpoint when needed and refreshed before they expire, and the client
+// can be used from multiple goroutines while this happens. ctx is used for the token requests. The refresh
+// token may be empty when using WithTokenStore with a store that already has a token
+func WithOAuth2Config(ctx context.Context, clientId, clientSecret, refreshToken string) func(*Client) error {
+	return func(c *Client) error {
+		if clientId == "" || clientSecret == "" {
+			return fmt.Errorf(`oauth2 config requires a client id and a client secret`)
+		}
+		c.oauth2Ctx = ctx
+		c.oauth2Config = &oauth2.Config{
+			ClientID:     clientId,
+			ClientSecret: clientSecret,
+			Endpoint:     Endpoint,
+		}
+		c.refreshToken = refreshToken
+		return nil
+	}
+}
+
+// WithOAuth2Endpoint allows the user to use a custom oauth2 endpoint, such as a local fake token endpoint in tests.
+// It applies to WithOAuth2Config regardless of the order the options are passed in
+func WithOAuth2Endpoint(endpoint oauth2.Endpoint) func(*Client) error {
+	return func(c *Client) error {
+		c.oauth2Endpoint = &endpoint
+		return nil
+	}
+}
+
+// WithTokenRefreshHook allows the user to be notified every time WithOAuth2Config refreshes the access token,
+// e.g. to persist the refresh token, which snapchat may rotate on refresh. If the hook returns an error the
+// request that needed the token fails, and the hook is called again with the next refreshed token
+func WithTokenRefreshHook(hook func(token *oauth2.Token) error) func(*Client) error {
+	return func(c *Client) error {
+		c.tokenRefreshHook = hook
+		return nil
+	}
+}
+
+// configureOAuth2 replaces the http client with one that authorizes requests with tokens refreshed using
+// the oauth2 config, token requests are sent with the http client that was configured before
+func (cli *Client) configureOAuth2() error {
+	if cli.refreshToken == "" && cli.tokenStore == nil {
+		return fmt.Errorf(`oauth2 config requires a refresh token or a token store`)
+	}
+	if cli.oauth2Endpoint != nil {
+		cli.oauth2Config.Endpoint = *cli.oauth2Endpoint
+	}
+	ctx := cli.oauth2Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if _, ok := ctx.Value(oauth2.HTTPClient).(*http.Client); !ok {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, cli.client)
+	}
+
+	if cli.oauth2Token != nil && cli.tokenStore != nil {
+		if err := cli.tokenStore.Save(ctx, cli.tokenStoreKey, cli.oauth2Token); err != nil {
+			return fmt.Errorf("save token to token store: %w", err)
+		}
+	}
+
+	source := &refreshingTokenSource{
+		ctx:          ctx,
+		config:       cli.oauth2Config,
+		refreshToken: cli.refreshToken,
+		hook:         cli.tokenRefreshHook,
+		store:        cli.tokenStore,
+		storeKey:     cli.tokenStoreKey,
+	}
+	tc := oauth2.NewClient(ctx, oauth2.ReuseTokenSource(cli.oauth2Token, source))
+	tc.Timeout = time.Minute
+	cli.client = tc
+	return nil
+}
+
+// refreshingTokenSource is a token source that requests a new access token with its refresh token every time
+// it is called, keeping track of the refresh token when snapchat rotates it. It is meant to be wrapped in an
+// oauth2.ReuseTokenSource so that tokens are only refreshed once they expire. If it has a token store, the
+// stored token is used while it is valid and the store's lock is held while refreshing
+type refreshingTokenSource struct {
+	ctx      context.Context
+	config   *oauth2.Config
+	hook     func(token *oauth2.Token) error
+	store    TokenStore
+	storeKey string
+
+	mu           sync.Mutex
+	refreshToken string
+}
+
+// Token returns the stored token if it is still valid, and refreshes the access token otherwise
+func (source *refreshingTokenSource) Token() (token *oauth2.Token, err error) {
+	source.mu.Lock()
+	defer source.mu.Unlock()
+
+	if source.store != nil {
+		unlock, lockErr := source.store.Lock(source.ctx, source.storeKey)
+		if lockErr != nil {
+			return nil, fmt.Errorf("lock token store: %w", lockErr)
+		}
+		// token and err are the named results, so a failed unlock is returned rather than a nil token
+		defer func() {
+			if unlockErr := unlock(); unlockErr != nil && err == nil {
+				token, err = nil, fmt.Errorf("unlock token store: %w", unlockErr)
+			}
+		}()
+
+		stored, loadErr := source.store.Load(source.ctx, source.storeKey)
+		if loadErr != nil {
+			return nil, fmt.Errorf("load token from token store: %w", loadErr)
+		}
+		if stored != nil {
+			if stored.Valid() {
+				return stored, nil
+			}
+			if stored.RefreshToken != "" {
+				source.refreshToken = stored.RefreshToken
+			}
+		}
+	}
+	if source.refreshToken == "" {
+		return nil, fmt.Errorf("no refresh token to refresh snapchat access token with")
+	}
+
+	token, err = source.config.TokenSource(source.ctx, &oauth2.Token{RefreshToken: source.refreshToken}).Token()
+	if err != nil {
+		return nil, fmt.Errorf("refresh snapchat access token: %w", err)
+	}
+	if token.RefreshToken == "" {
+		token.RefreshToken = source.refreshToken
+	}
+	source.refreshToken = token.RefreshToken
+
+	if source.store != nil {
+		if err := source.store.Save(source.ctx, source.storeKey, token); err != nil {
+			return nil, fmt.Errorf("save token to token store: %w", err)
+		}
+	}
+	if source.hook != nil {
+		if err := source.hook(token); err != nil {
+			return nil, fmt.Errorf("token refresh hook: %w", err)
+		}
+	}
+	return token, nil
+}
+
+// MarketingAPIScope is the oauth2 scope required to use the snapchat ads api
+const MarketingAPIScope = `snapchat-marketing-api`
+
+// Authorizer walks advertisers through snapchat's oauth2 consent flow using the authorization code grant with
+// PKCE, and creates clients authorized with the resulting tokens
+type Authorizer struct {
+	// ClientId is the client id of the oauth2 app
+	ClientId string
+	// ClientSecret is the client secret of the oauth2 app
+	ClientSecret string
+	// RedirectURL is the url snapchat redirects to after consent, it must match the oauth2 app's redirect url
+	RedirectURL string
+	// Scopes are the scopes to request, MarketingAPIScope is requested if empty
+	Scopes []string
+	// Endpoint is the oauth2 endpoint to use, Endpoint is used if its token url is empty
+	Endpoint oauth2.Endpoint
+}
+
+// AuthorizationRequest is a single attempt at the consent flow. State and Verifier must be kept, e.g. in the
+// user's session, until snapchat redirects back to the redirect url
+type AuthorizationRequest struct {
+	// URL is the url to send the advertiser to in order to grant access
+	URL string
+	// State is the random value that snapchat returns to the redirect url, to tie the redirect to this request
+	State string
+	// Verifier is the PKCE code verifier that is sent with the authorization code when exchanging it for tokens
+	Verifier string
+}
+
+// ErrConsentDenied is returned when the advertiser declined to grant access
+type ErrConsentDenied struct {
+	// Description is the reason given by snapchat, it may be empty
+	Description string
+}
+
+func (err *ErrConsentDenied) Error() string {
+	if err.Description == "" {
+		return "oauth2 consent denied"
+	}
+	return fmt.Sprintf("oauth2 consent denied: %s", err.Description)
+}
+
+func (err *ErrConsentDenied) Is(target error) bool {
+	_, ok := target.(*ErrConsentDenied)
+	return ok
+}
+
+// ErrStateMismatch is returned when the state returned to the redirect url does not match the authorization
+// request, which means the redirect did not result from that request
+type ErrStateMismatch struct{}
+
+func (err *ErrStateMismatch) Error() string {
+	return "oauth2 state mismatch"
+}
+
+func (err *ErrStateMismatch) Is(target error) bool {
+	_, ok := target.(*ErrStateMismatch)
+	return ok
+}
+
+// ErrAuthorizationFailed is returned when snapchat redirects back with an error other than a denied consent
+type ErrAuthorizationFailed struct {
+	// Code is the oauth2 error code, e.g. invalid_scope
+	Code string
+	// Description is the reason given by snapchat, it may be empty
+	Description string
+}
+
+func (err *ErrAuthorizationFailed) Error() string {
+	if err.Description == "" {
+		return fmt.Sprintf("oauth2 authorization failed: %s", err.Code)
+	}
+	return fmt.Sprintf("oauth2 authorization failed: %s: %s", err.Code, err.Description)
+}
+
+func (err *ErrAuthorizationFailed) Is(target error) bool {
+	_, ok := target.(*ErrAuthorizationFailed)
+	return ok
+}
+
+// AuthorizationRequest starts the consent flow, returning the url to send the advertiser to along with the
+// state and PKCE verifier to keep until the redirect
+func (auth *Authorizer) AuthorizationRequest() (*AuthorizationRequest, error) {
+	state := make([]byte, 32)
+	if _, err := rand.Read(state); err != nil {
+		return nil, fmt.Errorf("generate oauth2 state: %w", err)
+	}
+	req := &AuthorizationRequest{
+		State:    base64.RawURLEncoding.EncodeToString(state),
+		Verifier: oauth2.GenerateVerifier(),
+	}
+	req.URL = auth.config().AuthCodeURL(req.State, oauth2.S256ChallengeOption(req.Verifier))
+	return req, nil
+}
+
+// Exchange completes the consent flow with the query parameters snapchat redirected back with, validating them
+// against the authorization request and exchanging the authorization code for tokens. The state is checked
+// first, so a forged redirect returns an ErrStateMismatch even if it carries an error. It returns an
+// ErrConsentDenied if the advertiser declined
+func (auth *Authorizer) Exchange(ctx context.Context, request *AuthorizationRequest, query url.Values) (*oauth2.Token, error) {
+	if request.State == "" || subtle.ConstantTimeCompare([]byte(query.Get("state")), []byte(request.State)) != 1 {
+		return nil, &ErrStateMismatch{}
+	}
+	if code := query.Get("error"); code != "" {
+		if code == "access_denied" {
+			return nil, &ErrConsentDenied{Description: query.Get("error_description")}
+		}
+		return nil, &ErrAuthorizationFailed{Code: code, Description: query.Get("error_description")}
+	}
+	code := query.Get("code")
+	if code == "" {
+		return nil, &ErrAuthorizationFailed{Code: "missing_code", Description: "no authorization code in redirect"}
+	}
+
+	token, err := auth.config().Exchange(ctx, code, oauth2.VerifierOption(request.Verifier))
+	if err != nil {
+		return nil, fmt.Errorf("exchange oauth2 authorization code: %w", err)
+	}
+	return token, nil
+}
+
+// NewClient creates a new instance of Client authorized with the tokens returned by Exchange, refreshing the
+// access token as described in WithOAuth2Config. Any other optional functions are applied as in NewClient
+func (auth *Authorizer) NewClient(ctx context.Context, token *oauth2.Token, optFns ...func(*Client) error) (*Client, error) {
+	if token == nil || token.RefreshToken == "" {
+		return nil, fmt.Errorf(`oauth2 token has no refresh token`)
+	}
+	config := auth.config()
+	opts := []func(*Client) error{
+		WithOAuth2Config(ctx, config.ClientID, config.ClientSecret, token.RefreshToken),
+		WithOAuth2Endpoint(config.Endpoint),
+		func(c *Client) error {
+			c.oauth2Token = token
+			return nil
+		},
+	}
+	return NewClient(append(opts, optFns...)...)
+}
+
+// config returns the oauth2 config of the authorizer
+func (auth *Authorizer) config() *oauth2.Config {
+	scopes := auth.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{MarketingAPIScope}
+	}
+	endpoint := auth.Endpoint
+	if endpoint.TokenURL == "" {
+		endpoint = Endpoint
+	}
+	return &oauth2.Config{
+		ClientID:     auth.ClientId,
+		ClientSecret: auth.ClientSecret,
+		RedirectURL:  auth.RedirectURL,
+		Scopes:       scopes,
+		Endpoint:     endpoint,
+	}
+}
@@ -0,0 +1,237 @@
+package snapchat
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// newTokenServer returns a fake token endpoint that answers every refresh with access token "access-n" and refresh
+// token "refresh-n", n counting from 1, expiring after expiresIn(n) seconds, along with a func returning the refresh
+// tokens it received so far
+func newTokenServer(t *testing.T, expiresIn func(n int) int) (oauth2.Endpoint, func() []string) {
+	t.Helper()
+	var mu sync.Mutex
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "refresh_token" {
+			http.Error(w, `{"error":"invalid_request"}`, http.StatusBadRequest)
+			return
+		}
+		// widens the window in which concurrent requests wait for the same refresh
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		received = append(received, r.PostForm.Get("refresh_token"))
+		n := len(received)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"access-%d","refresh_token":"refresh-%d","token_type":"Bearer","expires_in":%d}`, n, n, expiresIn(n))
+	}))
+	t.Cleanup(server.Close)
+	endpoint := oauth2.Endpoint{TokenURL: server.URL, AuthStyle: oauth2.AuthStyleInParams}
+	return endpoint, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), received...)
+	}
+}
+
+func TestOAuth2RefreshesExpiredToken(t *testing.T) {
+	// tokens expiring within oauth2's expiry delta of 10 seconds are treated as expired right away
+	endpoint, received := newTokenServer(t, func(n int) int {
+		if n == 1 {
+			return 1
+		}
+		return 3600
+	})
+	server, recorded := newTestServer(t)
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	var hooked []string
+	cli, err := NewClient(
+		WithHost(server.URL),
+		WithOAuth2Config(ctx, "client", "secret", "refresh-0"),
+		WithOAuth2Endpoint(endpoint),
+		WithTokenRefreshHook(func(token *oauth2.Token) error {
+			mu.Lock()
+			defer mu.Unlock()
+			hooked = append(hooked, token.RefreshToken)
+			return nil
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := cli.Users.GetAuthenticatedUser(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tokens, _ := recorded()
+	if want := []string{"access-1", "access-2", "access-2"}; !reflect.DeepEqual(tokens, want) {
+		t.Errorf("requests sent access tokens %v, want %v", tokens, want)
+	}
+	// the second refresh must use the refresh token rotated by the first one
+	if want := []string{"refresh-0", "refresh-1"}; !reflect.DeepEqual(received(), want) {
+		t.Errorf("token endpoint received refresh tokens %v, want %v", received(), want)
+	}
+	if want := []string{"refresh-1", "refresh-2"}; !reflect.DeepEqual(hooked, want) {
+		t.Errorf("refresh hook received refresh tokens %v, want %v", hooked, want)
+	}
+}
+
+func TestOAuth2ConcurrentRequestsRefreshOnce(t *testing.T) {
+	endpoint, received := newTokenServer(t, func(int) int { return 3600 })
+	server, recorded := newTestServer(t)
+	ctx := context.Background()
+	cli, err := NewClient(
+		WithHost(server.URL),
+		WithOAuth2Config(ctx, "client", "secret", "refresh-0"),
+		WithOAuth2Endpoint(endpoint),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const workers = 20
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := cli.Users.GetAuthenticatedUser(ctx); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := received(); len(got) != 1 {
+		t.Errorf("token endpoint was called %d times, want 1", len(got))
+	}
+	tokens, _ := recorded()
+	if len(tokens) != workers {
+		t.Fatalf("expected %d requests, got %d", workers, len(tokens))
+	}
+	for i, token := range tokens {
+		if token != "access-1" {
+			t.Errorf("request %d sent access token %q, want access-1", i, token)
+		}
+	}
+}
+
+func TestAuthorizationRequest(t *testing.T) {
+	auth := &Authorizer{ClientId: "client", ClientSecret: "secret", RedirectURL: "https://example.com/callback"}
+	req, err := auth.AuthorizationRequest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.State == "" || req.Verifier == "" {
+		t.Fatalf("got state %q and verifier %q, want both", req.State, req.Verifier)
+	}
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := u.Query()
+	challenge := sha256.Sum256([]byte(req.Verifier))
+	want := map[string]string{
+		"client_id":             "client",
+		"redirect_uri":          "https://example.com/callback",
+		"response_type":         "code",
+		"scope":                 MarketingAPIScope,
+		"state":                 req.State,
+		"code_challenge_method": "S256",
+		"code_challenge":        base64.RawURLEncoding.EncodeToString(challenge[:]),
+	}
+	for key, value := range want {
+		if got := query.Get(key); got != value {
+			t.Errorf("url has %s %q, want %q", key, got, value)
+		}
+	}
+
+	other, err := auth.AuthorizationRequest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other.State == req.State || other.Verifier == req.Verifier {
+		t.Error("authorization requests share their state or verifier")
+	}
+}
+
+func TestExchange(t *testing.T) {
+	request := &AuthorizationRequest{State: "state", Verifier: "verifier"}
+	var exchanged url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Error(err)
+		}
+		exchanged = r.PostForm
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"access","refresh_token":"refresh","token_type":"Bearer","expires_in":3600}`)
+	}))
+	defer server.Close()
+	auth := &Authorizer{
+		ClientId:     "client",
+		ClientSecret: "secret",
+		RedirectURL:  "https://example.com/callback",
+		Endpoint:     oauth2.Endpoint{TokenURL: server.URL, AuthStyle: oauth2.AuthStyleInParams},
+	}
+
+	tests := []struct {
+		name    string
+		request *AuthorizationRequest
+		query   url.Values
+		wantErr error
+	}{
+		{"success", request, url.Values{"state": {"state"}, "code": {"code"}}, nil},
+		{"state mismatch", request, url.Values{"state": {"other"}, "code": {"code"}}, new(ErrStateMismatch)},
+		{"missing state", request, url.Values{"code": {"code"}}, new(ErrStateMismatch)},
+		{"request without state", &AuthorizationRequest{Verifier: "verifier"}, url.Values{"code": {"code"}}, new(ErrStateMismatch)},
+		{"forged error", request, url.Values{"state": {"other"}, "error": {"access_denied"}}, new(ErrStateMismatch)},
+		{"consent denied", request, url.Values{"state": {"state"}, "error": {"access_denied"}}, new(ErrConsentDenied)},
+		{"authorization failed", request, url.Values{"state": {"state"}, "error": {"invalid_scope"}}, new(ErrAuthorizationFailed)},
+		{"missing code", request, url.Values{"state": {"state"}}, new(ErrAuthorizationFailed)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			exchanged = nil
+			token, err := auth.Exchange(context.Background(), test.request, test.query)
+			if test.wantErr != nil {
+				if !errors.Is(err, test.wantErr) {
+					t.Errorf("got error %v, want %T", err, test.wantErr)
+				}
+				if exchanged != nil {
+					t.Error("the code was exchanged although the redirect was rejected")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if token.AccessToken != "access" || token.RefreshToken != "refresh" {
+				t.Errorf("got token %+v", token)
+			}
+			if got := exchanged.Get("code"); got != "code" {
+				t.Errorf("exchanged code %q, want code", got)
+			}
+			if got := exchanged.Get("code_verifier"); got != request.Verifier {
+				t.Errorf("exchanged with code verifier %q, want %q", got, request.Verifier)
+			}
+		})
+	}
+}
@@ -20,7 +20,9 @@ type Campaign struct {
 	// Name is the name of the campaign
 	Name string `json:"name"`
 	// Status is the status of the campaign (ACTIVE, PAUSED)
-	Status string `json:"status"`
+	Status Status `json:"status"`
+	// Objective is the business objective of the campaign
+	Objective Objective `json:"objective"`
 	// MeasurementSpec contains the apps to be tracked for this campaign
 	MeasurementSpec CampaignMeasurementSpec `json:"measurement_spec"`
 	// EndTime is the time when the campaign will end
@@ -32,9 +34,12 @@ type Campaign struct {
 	// UpdatedAt is the time when the campaign was last updated
 	UpdatedAt time.Time `json:"updated_at"`
 	// DailyBudgetMicro is the daily spend cap for the campaign(micro-currency)
-	DailyBudgetMicro int64 `json:"daily_budget_micro"`
+	DailyBudgetMicro Micro `json:"daily_budget_micro"`
 	// LifetimeSpendCapMicro is the lifetime spend cap for the campaign (microcurrency)
-	LifetimeSpendCapMicro int64 `json:"lifetime_spend_cap_micro"`
+	LifetimeSpendCapMicro Micro `json:"lifetime_spend_cap_micro"`
+	// ClearFields lists optional fields by json name, such as "daily_budget_micro", that are sent even if they
+	// are zero, e.g. to remove the daily budget with Update. Optional fields are not sent while they are zero otherwise
+	ClearFields []string `json:"-"`
 }
 
 // GetCampaignsResponse is the response object returned when getting campaigns
@@ -42,12 +47,33 @@ type GetCampaignsResponse struct {
 	RequestStatus string              `json:"request_status"`
 	RequestId     string              `json:"request_id"`
 	Campaigns     []*CampaignResponse `json:"campaigns"`
+	Paging        Paging              `json:"paging"`
 }
 
 // CampaignResponse is the object for a single campaign response
 type CampaignResponse struct {
-	SubRequestStatus string   `json:"sub_request_status"`
-	Campaign         Campaign `json:"campaign"`
+	SubRequestStatus      string   `json:"sub_request_status"`
+	SubRequestErrorReason string   `json:"sub_request_error_reason"`
+	Campaign              Campaign `json:"campaign"`
+}
+
+// campaignsRequest is the request object used when creating or updating campaigns
+type campaignsRequest struct {
+	Campaigns []*campaignRequest `json:"campaigns"`
+}
+
+// campaignRequest contains the campaign fields that can be sent when creating or updating a campaign
+type campaignRequest struct {
+	Id                    string                   `json:"id,omitempty"`
+	AdAccountId           string                   `json:"ad_account_id"`
+	Name                  string                   `json:"name,omitempty"`
+	Status                Status                   `json:"status,omitempty"`
+	Objective             Objective                `json:"objective,omitempty"`
+	MeasurementSpec       *CampaignMeasurementSpec `json:"measurement_spec,omitempty"`
+	StartTime             *time.Time               `json:"start_time,omitempty"`
+	EndTime               *time.Time               `json:"end_time,omitempty"`
+	DailyBudgetMicro      *Micro                   `json:"daily_budget_micro,omitempty"`
+	LifetimeSpendCapMicro *Micro                   `json:"lifetime_spend_cap_micro,omitempty"`
 }
 
 // CampaignMeasurementSpec contains the apps to be tracked for this campaign
@@ -80,27 +106,43 @@ func (cmp *CampaignService) Get(ctx context.Context, campaignId string) (*Campai
 	return nil, fmt.Errorf(`non-success status returned from snapchat api (get campaign): %s`, c.RequestStatus)
 }
 
-// List retrieves all campaigns within a specified ad account
-func (cmp *CampaignService) List(ctx context.Context, adAccountId string) ([]*Campaign, error) {
-	path := fmt.Sprintf(`adaccounts/%s/campaigns`, adAccountId)
-	req, err := cmp.client.createRequest("GET", path, nil)
+// List retrieves all campaigns within a specified ad account, following every page of results
+func (cmp *CampaignService) List(ctx context.Context, adAccountId string, opts ...func(*ListOptions)) ([]*Campaign, error) {
+	var results []*Campaign
+	err := collectPages(opts, func(pageOpts []func(*ListOptions)) (string, error) {
+		page, next, err := cmp.ListPage(ctx, adAccountId, pageOpts...)
+		results = append(results, page...)
+		return next, err
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	if len(results) > 0 {
+		return results, nil
+	}
+	return nil, fmt.Errorf("no campaigns found for ad account id: %s", adAccountId)
+}
+
+// ListPage returns a single page of the campaigns within a specified ad account, along with
+// the cursor to pass to WithPageCursor to get the next page. The cursor is empty on the last page
+func (cmp *CampaignService) ListPage(ctx context.Context, adAccountId string, opts ...func(*ListOptions)) ([]*Campaign, string, error) {
+	path := fmt.Sprintf(`adaccounts/%s/campaigns`, adAccountId)
+	req, err := cmp.client.createListRequest(path, opts)
+	if err != nil {
+		return nil, "", err
+	}
+
 	c := new(GetCampaignsResponse)
 	err = cmp.client.do(ctx, req, c)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	if strings.ToLower(c.RequestStatus) == "success" {
-		if len(c.Campaigns) > 0 {
-			return getCampaignsFromResponse(c.Campaigns), nil
-		}
-		return nil, fmt.Errorf("no campaigns found for ad account id: %s", adAccountId)
+		return getCampaignsFromResponse(c.Campaigns), c.Paging.cursor(), nil
 	}
-	return nil, fmt.Errorf(`non-success status returned from snapchat api (list campaigns): %s`, c.RequestStatus)
+	return nil, "", fmt.Errorf(`non-success status returned from snapchat api (list campaigns): %s`, c.RequestStatus)
 }
 
 // Delete deletes a specific campaign
@@ -123,6 +165,86 @@ func (cmp *CampaignService) Delete(ctx context.Context, campaignId string) error
 	return fmt.Errorf(`non-success status returned from snapchat api (delete campaign): %s`, c.RequestStatus)
 }
 
+// Create creates the provided campaigns within the specified ad account and returns them as created by the api,
+// including the ids assigned to them. The id and timestamps of the provided campaigns are not sent.
+// If some of the campaigns could not be created the others are still returned along with an ErrBatchFailure
+func (cmp *CampaignService) Create(ctx context.Context, adAccountId string, campaigns ...*Campaign) ([]*Campaign, error) {
+	body := new(campaignsRequest)
+	for i, campaign := range campaigns {
+		c, err := newCampaignRequest(adAccountId, campaign)
+		if err != nil {
+			return nil, fmt.Errorf("campaign at index %d: %w", i, err)
+		}
+		c.Id = ""
+		body.Campaigns = append(body.Campaigns, c)
+	}
+	return cmp.save(ctx, "POST", adAccountId, body)
+}
+
+// Update updates the provided campaigns within the specified ad account and returns them as updated by the api.
+// Fields that are empty or zero are not sent, use ClearFields to set an optional field to zero. If some of the campaigns could not be updated the others are still returned along with an ErrBatchFailure
+func (cmp *CampaignService) Update(ctx context.Context, adAccountId string, campaigns ...*Campaign) ([]*Campaign, error) {
+	body := new(campaignsRequest)
+	for i, campaign := range campaigns {
+		if campaign.Id == "" {
+			return nil, fmt.Errorf("campaign at index %d has no id", i)
+		}
+		c, err := newCampaignRequest(adAccountId, campaign)
+		if err != nil {
+			return nil, fmt.Errorf("campaign at index %d: %w", i, err)
+		}
+		body.Campaigns = append(body.Campaigns, c)
+	}
+	return cmp.save(ctx, "PUT", adAccountId, body)
+}
+
+// save sends a create or update request for campaigns within an ad account
+func (cmp *CampaignService) save(ctx context.Context, method, adAccountId string, body *campaignsRequest) ([]*Campaign, error) {
+	if len(body.Campaigns) == 0 {
+		return nil, fmt.Errorf("no campaigns provided")
+	}
+
+	path := fmt.Sprintf(`adaccounts/%s/campaigns`, adAccountId)
+	req, err := cmp.client.createRequest(method, path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	c := new(GetCampaignsResponse)
+	err = cmp.client.do(ctx, req, c)
+	if len(c.Campaigns) > 0 {
+		return getCampaignsFromBatchResponse(c.Campaigns, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf(`non-success status returned from snapchat api (save campaigns for ad account with id %s): %s`, adAccountId, c.RequestStatus)
+}
+
+// newCampaignRequest copies the writable fields of a campaign into a request object
+func newCampaignRequest(adAccountId string, campaign *Campaign) (*campaignRequest, error) {
+	cleared, err := clearedFields(campaign.ClearFields, "daily_budget_micro", "lifetime_spend_cap_micro")
+	if err != nil {
+		return nil, err
+	}
+	c := &campaignRequest{
+		Id:                    campaign.Id,
+		AdAccountId:           adAccountId,
+		Name:                  campaign.Name,
+		Status:                campaign.Status,
+		Objective:             campaign.Objective,
+		StartTime:             optionalTime(campaign.StartTime),
+		EndTime:               optionalTime(campaign.EndTime),
+		DailyBudgetMicro:      optionalValue(campaign.DailyBudgetMicro, cleared["daily_budget_micro"]),
+		LifetimeSpendCapMicro: optionalValue(campaign.LifetimeSpendCapMicro, cleared["lifetime_spend_cap_micro"]),
+	}
+	if campaign.MeasurementSpec != (CampaignMeasurementSpec{}) {
+		spec := campaign.MeasurementSpec
+		c.MeasurementSpec = &spec
+	}
+	return c, nil
+}
+
 func getCampaignsFromResponse(list []*CampaignResponse) []*Campaign {
 	var results []*Campaign
 	for _, val := range list {
@@ -132,3 +254,18 @@ func getCampaignsFromResponse(list []*CampaignResponse) []*Campaign {
 	}
 	return results
 }
+
+// getCampaignsFromBatchResponse returns the successful campaigns in a create or update response, along with
+// an ErrBatchFailure describing any campaigns that were not successful and wrapping requestErr, the error of the request
+func getCampaignsFromBatchResponse(list []*CampaignResponse, requestErr error) ([]*Campaign, error) {
+	var results []*Campaign
+	batchErr := new(ErrBatchFailure)
+	for i, val := range list {
+		if strings.ToLower(val.SubRequestStatus) == "success" {
+			results = append(results, &val.Campaign)
+			continue
+		}
+		batchErr.add(i, val.SubRequestStatus, val.SubRequestErrorReason)
+	}
+	return results, batchErr.wrap(requestErr)
+}
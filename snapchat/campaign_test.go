@@ -0,0 +1,74 @@
+package snapchat
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestNewCampaignRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		campaign Campaign
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "zero fields are not sent",
+			campaign: Campaign{Id: "id"},
+			want:     `{"id":"id","ad_account_id":"account"}`,
+		},
+		{
+			name:     "set fields are sent",
+			campaign: Campaign{Id: "id", Name: "name", Status: StatusPaused, DailyBudgetMicro: 20 * MicrosPerUnit},
+			want:     `{"id":"id","ad_account_id":"account","name":"name","status":"PAUSED","daily_budget_micro":20000000}`,
+		},
+		{
+			name:     "cleared fields are sent as zero",
+			campaign: Campaign{Id: "id", ClearFields: []string{"daily_budget_micro", "lifetime_spend_cap_micro"}},
+			want:     `{"id":"id","ad_account_id":"account","daily_budget_micro":0,"lifetime_spend_cap_micro":0}`,
+		},
+		{
+			name:     "unknown cleared field",
+			campaign: Campaign{Id: "id", ClearFields: []string{"name"}},
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req, err := newCampaignRequest("account", &test.campaign)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got error %v, want error %t", err, test.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			body, err := json.Marshal(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(body) != test.want {
+				t.Errorf("got %s, want %s", body, test.want)
+			}
+		})
+	}
+}
+
+func TestCampaignsCreateReportsFailuresOfFailedBatch(t *testing.T) {
+	cli := newBatchTestClient(t, 400, `{"request_status":"ERROR","campaigns":[`+
+		`{"sub_request_status":"SUCCESS","campaign":{"id":"created"}},`+
+		`{"sub_request_status":"ERROR","sub_request_error_reason":"bad name"}]}`)
+
+	campaigns, err := cli.Campaigns.Create(context.Background(), "account", &Campaign{Name: "good"}, &Campaign{Name: "bad"})
+	if len(campaigns) != 1 || campaigns[0].Id != "created" {
+		t.Errorf("got campaigns %v, want the created campaign", campaigns)
+	}
+	var batchErr *ErrBatchFailure
+	if !errors.As(err, &batchErr) || len(batchErr.Failures) != 1 || batchErr.Failures[0].Index != 1 {
+		t.Fatalf("got error %v, want an ErrBatchFailure for the second campaign", err)
+	}
+	if !errors.Is(err, new(ErrBadRequest)) {
+		t.Errorf("got error %v, want it to wrap the api error", err)
+	}
+}
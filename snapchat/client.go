@@ -2,8 +2,10 @@ package snapchat
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"os"
+	"sync"
 	"time"
 
 	"golang.org/x/oauth2"
@@ -14,6 +16,10 @@ const (
 	DefaultSnapchatHost = `https://adsapi.snapchat.com`
 	// DefaultSnapchatVersion is the default version to use for calls to the snapchat ads api
 	DefaultSnapchatVersion = `v1`
+	// DefaultConversionsHost is the default host address to use for calls to the snapchat conversions api
+	DefaultConversionsHost = `https://tr.snapchat.com`
+	// ConversionsVersion is the version of the snapchat conversions api used by the client
+	ConversionsVersion = `v2`
 )
 
 type service struct {
@@ -24,6 +30,10 @@ type service struct {
 type Client struct {
 	// host holds the address of the snapchat server
 	host string
+	// conversionsHost holds the address of the snapchat conversions api server
+	conversionsHost string
+	// mu guards client, customHTTPHeaders and accessToken, which may be updated while requests are in flight
+	mu sync.RWMutex
 	// client handles http requests
 	client *http.Client
 	// version of the snapchat api to use
@@ -34,6 +44,24 @@ type Client struct {
 	customVersion bool
 	// accessToken is the user's access token to use for authorization
 	accessToken string
+	// oauth2Ctx is the context used for token requests when authorizing with an oauth2 config
+	oauth2Ctx context.Context
+	// oauth2Config is the user's oauth2 app, access tokens are refreshed with it if set
+	oauth2Config *oauth2.Config
+	// oauth2Endpoint overrides the oauth2 endpoint of oauth2Config if set
+	oauth2Endpoint *oauth2.Endpoint
+	// refreshToken is the refresh token used with oauth2Config
+	refreshToken string
+	// oauth2Token is the token used with oauth2Config until it expires, it may be nil
+	oauth2Token *oauth2.Token
+	// tokenRefreshHook is called every time the access token is refreshed with oauth2Config
+	tokenRefreshHook func(token *oauth2.Token) error
+	// tokenStore shares the tokens refreshed with oauth2Config if set
+	tokenStore TokenStore
+	// tokenStoreKey is the key of the token in tokenStore
+	tokenStoreKey string
+	// retryPolicy controls how failed requests are retried, requests are not retried if nil
+	retryPolicy *RetryPolicy
 	// Users is the service used to get the authenticated user
 	Users *UserService
 	// Organizations is the service used to interact with organizations
@@ -50,6 +78,16 @@ type Client struct {
 	Ads *AdService
 	// Measurements is the service used to interact with ads
 	Measurements *MeasurementService
+	// Creatives is the service used to interact with creatives
+	Creatives *CreativeService
+	// Media is the service used to interact with media and upload files
+	Media *MediaService
+	// Segments is the service used to interact with audience segments
+	Segments *SegmentService
+	// Pixels is the service used to interact with snap pixels
+	Pixels *PixelService
+	// Conversions is the service used to send server side events to the conversions api
+	Conversions *ConversionService
 }
 
 // NewClient creates a new instance of Client with any optional functions applied
@@ -59,9 +97,10 @@ func NewClient(optFns ...func(*Client) error) (*Client, error) {
 		return nil, err
 	}
 	c := &Client{
-		host:    DefaultSnapchatHost,
-		version: DefaultSnapchatVersion,
-		client:  client,
+		host:            DefaultSnapchatHost,
+		conversionsHost: DefaultConversionsHost,
+		version:         DefaultSnapchatVersion,
+		client:          client,
 	}
 	c.Users = &UserService{client: c}
 	c.Organizations = &OrganizationService{client: c}
@@ -71,12 +110,22 @@ func NewClient(optFns ...func(*Client) error) (*Client, error) {
 	c.AdSquads = &AdSquadService{client: c}
 	c.Ads = &AdService{client: c}
 	c.Measurements = &MeasurementService{client: c}
+	c.Creatives = &CreativeService{client: c}
+	c.Media = &MediaService{client: c}
+	c.Segments = &SegmentService{client: c}
+	c.Pixels = &PixelService{client: c}
+	c.Conversions = &ConversionService{client: c}
 
 	for _, fn := range optFns {
 		if err := fn(c); err != nil {
 			return nil, err
 		}
 	}
+	if c.oauth2Config != nil {
+		if err := c.configureOAuth2(); err != nil {
+			return nil, err
+		}
+	}
 	return c, nil
 }
 
@@ -94,14 +143,31 @@ func WithAccessToken(ctx context.Context, accessToken string) func(*Client) erro
 	}
 }
 
-// UpdateAccessToken can be used to update an outdated access token
+// UpdateAccessToken can be used to update an outdated access token. It is safe to call while other
+// goroutines are using the client, requests already in flight complete with the previous token.
+// It does nothing if the client was created with WithOAuth2Config, since the access token is then refreshed
+// automatically, use SetAccessToken to get an error in that case
 func (cli *Client) UpdateAccessToken(ctx context.Context, accessToken string) {
-	cli.accessToken = accessToken
+	_ = cli.SetAccessToken(ctx, accessToken)
+}
+
+// SetAccessToken is the same as UpdateAccessToken, but returns an error without changing the client if the
+// client was created with WithOAuth2Config, since replacing the access token would stop the refreshes
+func (cli *Client) SetAccessToken(ctx context.Context, accessToken string) error {
+	if cli.oauth2Config != nil {
+		return fmt.Errorf(`cannot update the access token of a client created with an oauth2 config, it is refreshed automatically`)
+	}
 	ts := oauth2.StaticTokenSource(
 		&oauth2.Token{AccessToken: accessToken},
 	)
 	tc := oauth2.NewClient(ctx, ts)
+	tc.Timeout = time.Minute
+
+	cli.mu.Lock()
+	defer cli.mu.Unlock()
+	cli.accessToken = accessToken
 	cli.client = tc
+	return nil
 }
 
 // WithHTTPClient allows user to provide a custom client
@@ -114,6 +180,20 @@ func WithHTTPClient(client *http.Client) func(*Client) error {
 	}
 }
 
+// WithRetryPolicy allows the user to retry idempotent requests that fail with a 429 or 5xx status code
+func WithRetryPolicy(policy RetryPolicy) func(*Client) error {
+	return func(c *Client) error {
+		if policy.MaxAttempts < 1 {
+			return fmt.Errorf(`retry policy max attempts must be at least 1, got %d`, policy.MaxAttempts)
+		}
+		if policy.BaseDelay < 0 || policy.MaxDelay < 0 {
+			return fmt.Errorf(`retry policy delays must not be negative`)
+		}
+		c.retryPolicy = &policy
+		return nil
+	}
+}
+
 // WithHost allows the user to use a custom snapchat ads api host
 func WithHost(host string) func(*Client) error {
 	return func(c *Client) error {
@@ -122,6 +202,14 @@ func WithHost(host string) func(*Client) error {
 	}
 }
 
+// WithConversionsHost allows the user to use a custom snapchat conversions api host
+func WithConversionsHost(host string) func(*Client) error {
+	return func(c *Client) error {
+		c.conversionsHost = host
+		return nil
+	}
+}
+
 // WithVersion allows the user to use a custom snapchat ads api version
 func WithVersion(version string) func(*Client) error {
 	return func(c *Client) error {
@@ -131,16 +219,32 @@ func WithVersion(version string) func(*Client) error {
 	}
 }
 
-// WithHTTPHeaders allows the user to specify custom headers to be used with all requests
+// WithHTTPHeaders allows the user to specify custom headers to be used with all requests. The headers are set on
+// every request sent to the ads and conversions apis, after the default headers, so they can override them
 func WithHTTPHeaders(headers map[string]string) func(*Client) error {
 	return func(c *Client) error {
-		c.customHTTPHeaders = headers
+		c.UpdateHTTPHeaders(headers)
 		return nil
 	}
 }
 
+// UpdateHTTPHeaders replaces the custom headers used with all requests. It is safe to call while other
+// goroutines are using the client
+func (cli *Client) UpdateHTTPHeaders(headers map[string]string) {
+	copied := make(map[string]string, len(headers))
+	for k, v := range headers {
+		copied[k] = v
+	}
+
+	cli.mu.Lock()
+	defer cli.mu.Unlock()
+	cli.customHTTPHeaders = copied
+}
+
 // GetCustomHTTPHeaders returns custom http headers stored by the client
 func (cli *Client) GetCustomHTTPHeaders() map[string]string {
+	cli.mu.RLock()
+	defer cli.mu.RUnlock()
 	headers := make(map[string]string)
 	for k, v := range cli.customHTTPHeaders {
 		headers[k] = v
@@ -148,6 +252,13 @@ func (cli *Client) GetCustomHTTPHeaders() map[string]string {
 	return headers
 }
 
+// httpClient returns the http client currently used to send requests
+func (cli *Client) httpClient() *http.Client {
+	cli.mu.RLock()
+	defer cli.mu.RUnlock()
+	return cli.client
+}
+
 // WithEnvVars allows retrieves host/version from environment variables
 func WithEnvVars(c *Client) error {
 	if host := os.Getenv("TWITTER_ADS_HOST"); host != "" {
@@ -0,0 +1,130 @@
+package snapchat
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// newTestServer returns a server that answers every request as the authenticated user endpoint and records the
+// authorization and X-Test headers of the requests it received
+func newTestServer(t *testing.T) (*httptest.Server, func() (tokens, headers []string)) {
+	t.Helper()
+	var mu sync.Mutex
+	var tokens, headers []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		tokens = append(tokens, strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+		headers = append(headers, r.Header.Get("X-Test"))
+		mu.Unlock()
+		fmt.Fprint(w, `{"request_status":"SUCCESS","me":{"id":"user"}}`)
+	}))
+	t.Cleanup(server.Close)
+	return server, func() ([]string, []string) {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), tokens...), append([]string(nil), headers...)
+	}
+}
+
+func TestClientConcurrentRequestsAndUpdates(t *testing.T) {
+	server, recorded := newTestServer(t)
+	ctx := context.Background()
+	cli, err := NewClient(
+		WithHost(server.URL),
+		WithAccessToken(ctx, "token-0"),
+		WithHTTPHeaders(map[string]string{"X-Test": "header-0"}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const workers, updates = 20, 20
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 5; j++ {
+				if _, err := cli.Users.GetAuthenticatedUser(ctx); err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+	}
+	for i := 1; i <= updates; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			cli.UpdateAccessToken(ctx, fmt.Sprintf("token-%d", i))
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			cli.UpdateHTTPHeaders(map[string]string{"X-Test": fmt.Sprintf("header-%d", i)})
+			_ = cli.GetCustomHTTPHeaders()
+		}(i)
+	}
+	wg.Wait()
+
+	tokens, headers := recorded()
+	if len(tokens) != workers*5 {
+		t.Fatalf("expected %d requests, got %d", workers*5, len(tokens))
+	}
+	for i := range tokens {
+		if !strings.HasPrefix(tokens[i], "token-") {
+			t.Errorf("request %d sent unexpected token %q", i, tokens[i])
+		}
+		if !strings.HasPrefix(headers[i], "header-") {
+			t.Errorf("request %d sent unexpected header %q", i, headers[i])
+		}
+	}
+}
+
+func TestClientSendsUpdatedTokenAndHeaders(t *testing.T) {
+	server, recorded := newTestServer(t)
+	ctx := context.Background()
+	headers := map[string]string{"X-Test": "before"}
+	cli, err := NewClient(WithHost(server.URL), WithAccessToken(ctx, "before"), WithHTTPHeaders(headers))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// changing the map passed to WithHTTPHeaders must not change the client's headers
+	headers["X-Test"] = "changed"
+
+	if _, err := cli.Users.GetAuthenticatedUser(ctx); err != nil {
+		t.Fatal(err)
+	}
+	cli.UpdateAccessToken(ctx, "after")
+	cli.UpdateHTTPHeaders(map[string]string{"X-Test": "after"})
+	if _, err := cli.Users.GetAuthenticatedUser(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	tokens, sent := recorded()
+	want := []string{"before", "after"}
+	for i := range want {
+		if tokens[i] != want[i] || sent[i] != want[i] {
+			t.Errorf("request %d sent token %q and header %q, want %q", i, tokens[i], sent[i], want[i])
+		}
+	}
+}
+
+func TestSetAccessTokenWithOAuth2Config(t *testing.T) {
+	ctx := context.Background()
+	cli, err := NewClient(WithOAuth2Config(ctx, "client", "secret", "refresh"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := cli.httpClient()
+	if err := cli.SetAccessToken(ctx, "token"); err == nil {
+		t.Fatal("expected an error when setting the access token of an oauth2 client")
+	}
+	cli.UpdateAccessToken(ctx, "token")
+	if cli.httpClient() != before {
+		t.Error("http client was replaced")
+	}
+}
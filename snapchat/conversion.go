@@ -0,0 +1,309 @@
+package snapchat
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxConversionEventsPerRequest is the largest number of events the conversions api accepts in a single request
+const MaxConversionEventsPerRequest = 2000
+
+// conversionEventTypes is the set of event types accepted by the conversions api
+var conversionEventTypes = map[string]bool{
+	"PAGE_VIEW": true, "VIEW_CONTENT": true, "ADD_CART": true, "ADD_TO_WISHLIST": true, "START_CHECKOUT": true,
+	"ADD_BILLING": true, "PURCHASE": true, "SIGN_UP": true, "SEARCH": true, "SAVE": true, "SUBSCRIBE": true,
+	"START_TRIAL": true, "APP_INSTALL": true, "APP_OPEN": true, "LEVEL_COMPLETE": true, "ACHIEVEMENT_UNLOCKED": true,
+	"LIST_VIEW": true, "LOGIN": true, "SHARE": true, "RESERVE": true, "INVITE": true, "COMPLETE_TUTORIAL": true,
+	"RATE": true, "AD_CLICK": true, "AD_VIEW": true, "SPENT_CREDITS": true, "CUSTOM_EVENT_1": true,
+	"CUSTOM_EVENT_2": true, "CUSTOM_EVENT_3": true, "CUSTOM_EVENT_4": true, "CUSTOM_EVENT_5": true,
+}
+
+// ConversionService provides functions for sending server side events to the snapchat conversions api
+type ConversionService service
+
+// ConversionEvent is a single server side event sent to the conversions api.
+// User data must be hashed, use the Set methods to normalize and hash raw values
+type ConversionEvent struct {
+	// PixelId is the id of the pixel the event is attributed to, required for WEB and OFFLINE events
+	PixelId string `json:"pixel_id,omitempty"`
+	// SnapAppId is the snap app id the event is attributed to, required for MOBILE_APP events
+	SnapAppId string `json:"snap_app_id,omitempty"`
+	// AppId is the ios app id or android package name, used with MOBILE_APP events
+	AppId string `json:"app_id,omitempty"`
+	// EventType is the type of the event (PURCHASE, ADD_CART, SIGN_UP, ...)
+	EventType string `json:"event_type"`
+	// EventConversionType is where the event happened (WEB, MOBILE_APP, OFFLINE)
+	EventConversionType string `json:"event_conversion_type"`
+	// EventTag is an optional tag used to differentiate events of the same type
+	EventTag string `json:"event_tag,omitempty"`
+	// Timestamp is the time when the event happened
+	Timestamp time.Time `json:"-"`
+	// HashedEmail is the SHA-256 hash of the normalized email address of the user
+	HashedEmail string `json:"hashed_email,omitempty"`
+	// HashedPhoneNumber is the SHA-256 hash of the normalized phone number of the user
+	HashedPhoneNumber string `json:"hashed_phone_number,omitempty"`
+	// HashedMobileAdId is the SHA-256 hash of the normalized mobile advertising id of the user
+	HashedMobileAdId string `json:"hashed_mobile_ad_id,omitempty"`
+	// HashedIpAndUserAgent is the SHA-256 hash of the ip address and user agent of the user
+	HashedIpAndUserAgent string `json:"hashed_ip_and_user_agent,omitempty"`
+	// UserAgent is the user agent of the user's browser
+	UserAgent string `json:"user_agent,omitempty"`
+	// ClickId is the snapchat click id (ScCid) of the ad the user clicked
+	ClickId string `json:"click_id,omitempty"`
+	// CookieId is the value of the _scid cookie set by the snap pixel
+	CookieId string `json:"uuid_c1,omitempty"`
+	// ClientDedupId is an id used to deduplicate the event with the same event sent by the snap pixel
+	ClientDedupId string `json:"client_dedup_id,omitempty"`
+	// TransactionId is the id of the transaction, also used to deduplicate purchase events
+	TransactionId string `json:"transaction_id,omitempty"`
+	// PageUrl is the url of the page where the event happened
+	PageUrl string `json:"page_url,omitempty"`
+	// Price is the total value of the event
+	Price string `json:"price,omitempty"`
+	// Currency is the three letter currency code of the price
+	Currency string `json:"currency,omitempty"`
+	// ItemIds is a list of ids of the items the event relates to
+	ItemIds []string `json:"item_ids,omitempty"`
+	// ItemCategory is the category of the items the event relates to
+	ItemCategory string `json:"item_category,omitempty"`
+	// NumberItems is the number of items the event relates to
+	NumberItems string `json:"number_items,omitempty"`
+	// Description is a description of the event
+	Description string `json:"description,omitempty"`
+	// SearchString is the search string of a SEARCH event
+	SearchString string `json:"search_string,omitempty"`
+	// SignUpMethod is the sign up method of a SIGN_UP event
+	SignUpMethod string `json:"sign_up_method,omitempty"`
+}
+
+// MarshalJSON encodes the event, sending the timestamp in milliseconds as expected by the api
+func (event *ConversionEvent) MarshalJSON() ([]byte, error) {
+	type conversionEvent ConversionEvent
+	return json.Marshal(&struct {
+		*conversionEvent
+		Timestamp string `json:"timestamp"`
+	}{
+		conversionEvent: (*conversionEvent)(event),
+		Timestamp:       strconv.FormatInt(event.Timestamp.UnixNano()/int64(time.Millisecond), 10),
+	})
+}
+
+// SetEmail normalizes and hashes an email address into HashedEmail
+func (event *ConversionEvent) SetEmail(email string) error {
+	hash, err := HashIdentifier(SchemaEmail, email)
+	if err != nil {
+		return err
+	}
+	event.HashedEmail = hash
+	return nil
+}
+
+// SetPhoneNumber normalizes and hashes a phone number in international format, starting with + or 00 and the
+// country code, into HashedPhoneNumber
+func (event *ConversionEvent) SetPhoneNumber(phone string) error {
+	hash, err := HashIdentifier(SchemaPhone, phone)
+	if err != nil {
+		return err
+	}
+	event.HashedPhoneNumber = hash
+	return nil
+}
+
+// SetMobileAdId normalizes and hashes a mobile advertising id into HashedMobileAdId
+func (event *ConversionEvent) SetMobileAdId(mobileAdId string) error {
+	hash, err := HashIdentifier(SchemaMobileAdId, mobileAdId)
+	if err != nil {
+		return err
+	}
+	event.HashedMobileAdId = hash
+	return nil
+}
+
+// SetIpAndUserAgent hashes the concatenated ip address and user agent into HashedIpAndUserAgent
+func (event *ConversionEvent) SetIpAndUserAgent(ip, userAgent string) {
+	event.HashedIpAndUserAgent = hashString(strings.TrimSpace(ip) + strings.TrimSpace(userAgent))
+}
+
+// Validate checks that the event has the fields required by the conversions api
+func (event *ConversionEvent) Validate() error {
+	switch event.EventConversionType {
+	case "WEB", "OFFLINE":
+		if event.PixelId == "" {
+			return fmt.Errorf("pixel id is required for %s events", event.EventConversionType)
+		}
+	case "MOBILE_APP":
+		if event.SnapAppId == "" || event.AppId == "" {
+			return fmt.Errorf("snap app id and app id are required for MOBILE_APP events")
+		}
+	default:
+		return fmt.Errorf("unknown event conversion type: %q", event.EventConversionType)
+	}
+	if !conversionEventTypes[event.EventType] {
+		return fmt.Errorf("unknown event type: %q", event.EventType)
+	}
+	if event.Timestamp.IsZero() {
+		return fmt.Errorf("timestamp is required")
+	}
+	if event.HashedEmail == "" && event.HashedPhoneNumber == "" && event.HashedMobileAdId == "" && event.HashedIpAndUserAgent == "" {
+		return fmt.Errorf("at least one of hashed email, phone number, mobile ad id or ip and user agent is required")
+	}
+	hashes := []struct{ name, value string }{
+		{"hashed email", event.HashedEmail},
+		{"hashed phone number", event.HashedPhoneNumber},
+		{"hashed mobile ad id", event.HashedMobileAdId},
+		{"hashed ip and user agent", event.HashedIpAndUserAgent},
+	}
+	for _, hash := range hashes {
+		if hash.value != "" && !isSHA256Hex(hash.value) {
+			return fmt.Errorf("%s is not a hex encoded SHA-256 hash", hash.name)
+		}
+	}
+	if event.Price != "" {
+		if _, err := strconv.ParseFloat(event.Price, 64); err != nil {
+			return fmt.Errorf("price is not a number: %q", event.Price)
+		}
+		if len(event.Currency) != 3 {
+			return fmt.Errorf("a three letter currency code is required with a price")
+		}
+	}
+	return nil
+}
+
+// ConversionResponse is the response object returned by the conversions api
+type ConversionResponse struct {
+	// Status is the status of the request (SUCCESS, PARTIAL, FAILED, VALID, INVALID)
+	Status string `json:"status"`
+	// Reason describes why the request was not successful
+	Reason string `json:"reason"`
+	// ErrorRecords lists the events that were rejected
+	ErrorRecords []*ConversionErrorRecord `json:"error_records"`
+}
+
+// ConversionErrorRecord describes events rejected by the conversions api for the same reason
+type ConversionErrorRecord struct {
+	// RecordIndex is the list of positions of the rejected events within the request
+	RecordIndex []int `json:"record_index"`
+	// Reason describes why the events were rejected
+	Reason string `json:"reason"`
+}
+
+// EventFailure describes a single event that failed validation, either locally or by the conversions api
+type EventFailure struct {
+	// Index is the position of the event in the events passed to Send or Test
+	Index int
+	// Reason describes why the event failed validation
+	Reason string
+}
+
+// ErrInvalidEvents is the error returned when one or more conversion events failed validation
+type ErrInvalidEvents struct {
+	// Failures holds an entry for every event that failed validation
+	Failures []*EventFailure
+	// Err is the error of a batch request that failed as a whole after other events were already rejected, the
+	// events of the following batches were not sent. It is nil if every batch was sent
+	Err error
+}
+
+func (err *ErrInvalidEvents) Error() string {
+	reasons := make([]string, len(err.Failures))
+	for i, failure := range err.Failures {
+		reasons[i] = fmt.Sprintf(`event %d: %s`, failure.Index, failure.Reason)
+	}
+	msg := fmt.Sprintf(`%d conversion events are invalid: %s`, len(err.Failures), strings.Join(reasons, "; "))
+	if err.Err != nil {
+		msg += fmt.Sprintf(` (%s)`, err.Err)
+	}
+	return msg
+}
+
+// Unwrap returns the error of the batch request that failed as a whole, if any
+func (err *ErrInvalidEvents) Unwrap() error {
+	return err.Err
+}
+
+// wrap returns the error wrapping requestErr, the error of a batch request, if any events were rejected,
+// and requestErr otherwise
+func (err *ErrInvalidEvents) wrap(requestErr error) error {
+	if len(err.Failures) == 0 {
+		return requestErr
+	}
+	err.Err = requestErr
+	return err
+}
+
+// Send validates the provided events and sends them to the conversions api, in batches of up to
+// MaxConversionEventsPerRequest. Nothing is sent if any event fails local validation. Events rejected by the
+// api are reported in an ErrInvalidEvents, the other events in the batch are still recorded. If a batch fails
+// as a whole the following batches are not sent, and its error is returned wrapped in an ErrInvalidEvents if
+// events of earlier batches were rejected
+func (cnv *ConversionService) Send(ctx context.Context, events ...*ConversionEvent) error {
+	return cnv.send(ctx, "conversion", events)
+}
+
+// Test validates the provided events locally and with the conversions api without recording them,
+// reporting any invalid events in an ErrInvalidEvents
+func (cnv *ConversionService) Test(ctx context.Context, events ...*ConversionEvent) error {
+	return cnv.send(ctx, "conversion/validate", events)
+}
+
+// send validates events and sends them to a conversions api path in batches
+func (cnv *ConversionService) send(ctx context.Context, path string, events []*ConversionEvent) error {
+	if len(events) == 0 {
+		return fmt.Errorf("no conversion events provided")
+	}
+	invalid := new(ErrInvalidEvents)
+	for i, event := range events {
+		if err := event.Validate(); err != nil {
+			invalid.Failures = append(invalid.Failures, &EventFailure{Index: i, Reason: err.Error()})
+		}
+	}
+	if len(invalid.Failures) > 0 {
+		return invalid
+	}
+
+	url := fmt.Sprintf(`%s/%s/%s`, cnv.client.conversionsHost, ConversionsVersion, path)
+	for start := 0; start < len(events); start += MaxConversionEventsPerRequest {
+		end := start + MaxConversionEventsPerRequest
+		if end > len(events) {
+			end = len(events)
+		}
+		req, err := cnv.client.createRequestForURL("POST", url, events[start:end])
+		if err != nil {
+			return err
+		}
+
+		c := new(ConversionResponse)
+		err = cnv.client.do(ctx, req, c)
+		for _, record := range c.ErrorRecords {
+			for _, index := range record.RecordIndex {
+				invalid.Failures = append(invalid.Failures, &EventFailure{Index: start + index, Reason: record.Reason})
+			}
+		}
+		if err != nil {
+			return invalid.wrap(fmt.Errorf("send events %d to %d: %w", start, end-1, err))
+		}
+		status := strings.ToUpper(c.Status)
+		if status != "SUCCESS" && status != "VALID" && len(c.ErrorRecords) == 0 {
+			return invalid.wrap(fmt.Errorf(`non-success status returned from snapchat conversions api: %s (%s)`, c.Status, c.Reason))
+		}
+	}
+	if len(invalid.Failures) > 0 {
+		return invalid
+	}
+	return nil
+}
+
+// isSHA256Hex returns true if s looks like a hex encoded SHA-256 hash
+func isSHA256Hex(s string) bool {
+	if len(s) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
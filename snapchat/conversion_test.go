@@ -0,0 +1,53 @@
+package snapchat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSendKeepsFailuresOfEarlierBatches(t *testing.T) {
+	var batches int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&batches, 1) == 1 {
+			fmt.Fprint(w, `{"status":"PARTIAL","error_records":[{"record_index":[1],"reason":"bad price"}]}`)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	cli, err := NewClient(WithConversionsHost(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events := make([]*ConversionEvent, MaxConversionEventsPerRequest+1)
+	for i := range events {
+		events[i] = &ConversionEvent{
+			PixelId:             "pixel",
+			EventType:           "PURCHASE",
+			EventConversionType: "WEB",
+			Timestamp:           time.Now(),
+		}
+		if err := events[i].SetEmail("user@example.com"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = cli.Conversions.Send(context.Background(), events...)
+	var invalid *ErrInvalidEvents
+	if !errors.As(err, &invalid) {
+		t.Fatalf("got error %v, want an ErrInvalidEvents", err)
+	}
+	if len(invalid.Failures) != 1 || invalid.Failures[0].Index != 1 || invalid.Failures[0].Reason != "bad price" {
+		t.Errorf("got failures %+v, want the event rejected in the first batch", invalid.Failures)
+	}
+	if !errors.Is(err, new(ErrInternalServerError)) {
+		t.Errorf("got error %v, want it to wrap the error of the second batch", err)
+	}
+}
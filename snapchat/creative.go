@@ -0,0 +1,342 @@
+package snapchat
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CreativeService provides functions for interacting with snapchat creatives
+type CreativeService service
+
+// Creative represents a creative in the snapchat ads api, it holds the media and attachment shown by an ad
+type Creative struct {
+	// Id is the id representing a single creative
+	Id string `json:"id"`
+	// AdAccountId is the id of the ad account the creative is under
+	AdAccountId string `json:"ad_account_id"`
+	// Name is the name of the creative
+	Name string `json:"name"`
+	// Type is the type of the creative (SNAP_AD, APP_INSTALL, LONGFORM_VIDEO, WEB_VIEW, DEEP_LINK, COLLECTION)
+	Type string `json:"type"`
+	// PackagingStatus is the packaging status of the creative
+	PackagingStatus string `json:"packaging_status"`
+	// ReviewStatus is the status of the creative's review process
+	ReviewStatus string `json:"review_status"`
+	// ReviewStatusReason will contain a reason for rejection if a creative was rejected
+	ReviewStatusReason string `json:"review_status_reason"`
+	// Headline is the headline shown with the creative
+	Headline string `json:"headline"`
+	// BrandName is the brand name shown with the creative
+	BrandName string `json:"brand_name"`
+	// CallToAction is the call to action shown with the creative (e.g. INSTALL_NOW, VIEW_MORE)
+	CallToAction string `json:"call_to_action"`
+	// Shareable is true if users are able to share the creative
+	Shareable bool `json:"shareable"`
+	// TopSnapMediaId is the id of the media shown as the top snap
+	TopSnapMediaId string `json:"top_snap_media_id"`
+	// TopSnapCropPosition is the crop position of the top snap media (OPTIMIZED, MIDDLE, TOP, BOTTOM)
+	TopSnapCropPosition string `json:"top_snap_crop_position"`
+	// WebViewProperties are the properties of a WEB_VIEW creative
+	WebViewProperties *WebViewProperties `json:"web_view_properties"`
+	// AppInstallProperties are the properties of an APP_INSTALL creative
+	AppInstallProperties *AppInstallProperties `json:"app_install_properties"`
+	// DeepLinkProperties are the properties of a DEEP_LINK creative
+	DeepLinkProperties *DeepLinkProperties `json:"deep_link_properties"`
+	// LongformVideoProperties are the properties of a LONGFORM_VIDEO creative
+	LongformVideoProperties *LongformVideoProperties `json:"longform_video_properties"`
+	// CollectionProperties are the properties of a COLLECTION creative
+	CollectionProperties *CollectionProperties `json:"collection_properties"`
+	// PreviewProperties are the properties used to show the creative in a preview, such as in discover
+	PreviewProperties *PreviewProperties `json:"preview_properties"`
+	// CreatedAt is the time when the creative was created
+	CreatedAt time.Time `json:"created_at"`
+	// UpdatedAt is the time when the creative was last updated
+	UpdatedAt time.Time `json:"updated_at"`
+	// ClearFields lists optional fields by json name, such as "shareable", that are sent even if they are empty
+	// or false, e.g. to stop a creative from being shareable with Update. They are not sent while empty otherwise
+	ClearFields []string `json:"-"`
+}
+
+// WebViewProperties are the properties of a creative that opens a web page
+type WebViewProperties struct {
+	// Url is the url of the web page
+	Url string `json:"url"`
+	// AllowSnapJavascriptSdk allows the web page to use the snap javascript sdk
+	AllowSnapJavascriptSdk bool `json:"allow_snap_javascript_sdk,omitempty"`
+	// UseImmersiveMode opens the web page in immersive mode
+	UseImmersiveMode bool `json:"use_immersive_mode,omitempty"`
+	// DeepLinkUrls is a list of deep link urls the web page is allowed to open
+	DeepLinkUrls []string `json:"deep_link_urls,omitempty"`
+	// BlockPreload prevents the web page from being loaded before the user swipes up
+	BlockPreload bool `json:"block_preload,omitempty"`
+}
+
+// AppInstallProperties are the properties of a creative that installs an app
+type AppInstallProperties struct {
+	// AppName is the name of the app
+	AppName string `json:"app_name"`
+	// IosAppId is the ios app id of the app
+	IosAppId string `json:"ios_app_id,omitempty"`
+	// AndroidAppUrl is the android app url of the app
+	AndroidAppUrl string `json:"android_app_url,omitempty"`
+	// IconMediaId is the id of the media used as the app icon
+	IconMediaId string `json:"icon_media_id"`
+	// DeepLinkUri is the uri opened if the app is already installed
+	DeepLinkUri string `json:"deep_link_uri,omitempty"`
+}
+
+// DeepLinkProperties are the properties of a creative that opens an installed app
+type DeepLinkProperties struct {
+	// DeepLinkUri is the uri opened in the app
+	DeepLinkUri string `json:"deep_link_uri"`
+	// AppName is the name of the app
+	AppName string `json:"app_name"`
+	// IosAppId is the ios app id of the app
+	IosAppId string `json:"ios_app_id,omitempty"`
+	// AndroidAppUrl is the android app url of the app
+	AndroidAppUrl string `json:"android_app_url,omitempty"`
+	// IconMediaId is the id of the media used as the app icon
+	IconMediaId string `json:"icon_media_id"`
+	// FallbackType is what happens if the app is not installed (APP_INSTALL, WEB_VIEW)
+	FallbackType string `json:"fallback_type,omitempty"`
+	// WebViewFallbackUrl is the url opened if FallbackType is WEB_VIEW
+	WebViewFallbackUrl string `json:"web_view_fallback_url,omitempty"`
+}
+
+// LongformVideoProperties are the properties of a creative that plays a long form video
+type LongformVideoProperties struct {
+	// VideoMediaId is the id of the media of the long form video
+	VideoMediaId string `json:"video_media_id"`
+}
+
+// CollectionProperties are the properties of a creative that shows a collection of products
+type CollectionProperties struct {
+	// InteractionZoneId is the id of the interaction zone holding the products
+	InteractionZoneId string `json:"interaction_zone_id"`
+	// DefaultFallbackInteractionType is the attachment used when the top snap is swiped up (WEB_VIEW, DEEP_LINK)
+	DefaultFallbackInteractionType string `json:"default_fallback_interaction_type"`
+	// WebViewProperties are used if the default fallback interaction type is WEB_VIEW
+	WebViewProperties *WebViewProperties `json:"web_view_properties,omitempty"`
+	// DeepLinkProperties are used if the default fallback interaction type is DEEP_LINK
+	DeepLinkProperties *DeepLinkProperties `json:"deep_link_properties,omitempty"`
+}
+
+// PreviewProperties are the properties used to preview a creative
+type PreviewProperties struct {
+	// PreviewMediaId is the id of the media shown in the preview
+	PreviewMediaId string `json:"preview_media_id"`
+	// LogoMediaId is the id of the media shown as the logo in the preview
+	LogoMediaId string `json:"logo_media_id,omitempty"`
+	// PreviewHeadline is the headline shown in the preview
+	PreviewHeadline string `json:"preview_headline,omitempty"`
+}
+
+// GetCreativesResponse is the response object returned when getting creatives
+type GetCreativesResponse struct {
+	RequestStatus string              `json:"request_status"`
+	RequestId     string              `json:"request_id"`
+	Creatives     []*CreativeResponse `json:"creatives"`
+	Paging        Paging              `json:"paging"`
+}
+
+// CreativeResponse is the object for a single creative response
+type CreativeResponse struct {
+	SubRequestStatus      string   `json:"sub_request_status"`
+	SubRequestErrorReason string   `json:"sub_request_error_reason"`
+	Creative              Creative `json:"creative"`
+}
+
+// creativesRequest is the request object used when creating or updating creatives
+type creativesRequest struct {
+	Creatives []*creativeRequest `json:"creatives"`
+}
+
+// creativeRequest contains the creative fields that can be sent when creating or updating a creative
+type creativeRequest struct {
+	Id                      string                   `json:"id,omitempty"`
+	AdAccountId             string                   `json:"ad_account_id"`
+	Name                    string                   `json:"name,omitempty"`
+	Type                    string                   `json:"type,omitempty"`
+	Headline                *string                  `json:"headline,omitempty"`
+	BrandName               *string                  `json:"brand_name,omitempty"`
+	CallToAction            *string                  `json:"call_to_action,omitempty"`
+	Shareable               *bool                    `json:"shareable,omitempty"`
+	TopSnapMediaId          string                   `json:"top_snap_media_id,omitempty"`
+	TopSnapCropPosition     string                   `json:"top_snap_crop_position,omitempty"`
+	WebViewProperties       *WebViewProperties       `json:"web_view_properties,omitempty"`
+	AppInstallProperties    *AppInstallProperties    `json:"app_install_properties,omitempty"`
+	DeepLinkProperties      *DeepLinkProperties      `json:"deep_link_properties,omitempty"`
+	LongformVideoProperties *LongformVideoProperties `json:"longform_video_properties,omitempty"`
+	CollectionProperties    *CollectionProperties    `json:"collection_properties,omitempty"`
+	PreviewProperties       *PreviewProperties       `json:"preview_properties,omitempty"`
+}
+
+// Get retrieves a specific creative
+func (crt *CreativeService) Get(ctx context.Context, creativeId string) (*Creative, error) {
+	path := fmt.Sprintf(`creatives/%s`, creativeId)
+	req, err := crt.client.createRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c := new(GetCreativesResponse)
+	err = crt.client.do(ctx, req, c)
+	if err != nil {
+		return nil, err
+	}
+	if strings.ToLower(c.RequestStatus) == "success" {
+		if len(c.Creatives) > 0 {
+			return &c.Creatives[0].Creative, nil
+		}
+		return nil, fmt.Errorf("no creatives found with creative id: %s", creativeId)
+	}
+	return nil, fmt.Errorf(`non-success status returned from snapchat api (get creative with id %s): %s`, creativeId, c.RequestStatus)
+}
+
+// List retrieves all creatives within a specified ad account, following every page of results
+func (crt *CreativeService) List(ctx context.Context, adAccountId string, opts ...func(*ListOptions)) ([]*Creative, error) {
+	var results []*Creative
+	err := collectPages(opts, func(pageOpts []func(*ListOptions)) (string, error) {
+		page, next, err := crt.ListPage(ctx, adAccountId, pageOpts...)
+		results = append(results, page...)
+		return next, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) > 0 {
+		return results, nil
+	}
+	return nil, fmt.Errorf("no creatives found for ad account id: %s", adAccountId)
+}
+
+// ListPage returns a single page of the creatives within a specified ad account, along with
+// the cursor to pass to WithPageCursor to get the next page. The cursor is empty on the last page
+func (crt *CreativeService) ListPage(ctx context.Context, adAccountId string, opts ...func(*ListOptions)) ([]*Creative, string, error) {
+	path := fmt.Sprintf(`adaccounts/%s/creatives`, adAccountId)
+	req, err := crt.client.createListRequest(path, opts)
+	if err != nil {
+		return nil, "", err
+	}
+
+	c := new(GetCreativesResponse)
+	err = crt.client.do(ctx, req, c)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if strings.ToLower(c.RequestStatus) == "success" {
+		return getCreativesFromResponse(c.Creatives), c.Paging.cursor(), nil
+	}
+	return nil, "", fmt.Errorf(`non-success status returned from snapchat api (list creatives for ad account with id %s): %s`, adAccountId, c.RequestStatus)
+}
+
+// Create creates the provided creatives within the specified ad account and returns them as created by the api,
+// including the ids assigned to them. The id of the provided creatives is not sent.
+// If some of the creatives could not be created the others are still returned along with an ErrBatchFailure
+func (crt *CreativeService) Create(ctx context.Context, adAccountId string, creatives ...*Creative) ([]*Creative, error) {
+	body := new(creativesRequest)
+	for i, creative := range creatives {
+		c, err := newCreativeRequest(adAccountId, creative)
+		if err != nil {
+			return nil, fmt.Errorf("creative at index %d: %w", i, err)
+		}
+		c.Id = ""
+		body.Creatives = append(body.Creatives, c)
+	}
+	return crt.save(ctx, "POST", adAccountId, body)
+}
+
+// Update updates the provided creatives within the specified ad account and returns them as updated by the api.
+// Fields that are empty or false are not sent, use ClearFields to send an optional field anyway. If some of the creatives could not be updated the others are still returned along with an ErrBatchFailure
+func (crt *CreativeService) Update(ctx context.Context, adAccountId string, creatives ...*Creative) ([]*Creative, error) {
+	body := new(creativesRequest)
+	for i, creative := range creatives {
+		if creative.Id == "" {
+			return nil, fmt.Errorf("creative at index %d has no id", i)
+		}
+		c, err := newCreativeRequest(adAccountId, creative)
+		if err != nil {
+			return nil, fmt.Errorf("creative at index %d: %w", i, err)
+		}
+		body.Creatives = append(body.Creatives, c)
+	}
+	return crt.save(ctx, "PUT", adAccountId, body)
+}
+
+// save sends a create or update request for creatives within an ad account
+func (crt *CreativeService) save(ctx context.Context, method, adAccountId string, body *creativesRequest) ([]*Creative, error) {
+	if len(body.Creatives) == 0 {
+		return nil, fmt.Errorf("no creatives provided")
+	}
+
+	path := fmt.Sprintf(`adaccounts/%s/creatives`, adAccountId)
+	req, err := crt.client.createRequest(method, path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	c := new(GetCreativesResponse)
+	err = crt.client.do(ctx, req, c)
+	if len(c.Creatives) > 0 {
+		return getCreativesFromBatchResponse(c.Creatives, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf(`non-success status returned from snapchat api (save creatives for ad account with id %s): %s`, adAccountId, c.RequestStatus)
+}
+
+// newCreativeRequest copies the writable fields of a creative into a request object
+func newCreativeRequest(adAccountId string, creative *Creative) (*creativeRequest, error) {
+	cleared, err := clearedFields(creative.ClearFields, "headline", "brand_name", "call_to_action", "shareable")
+	if err != nil {
+		return nil, err
+	}
+	return &creativeRequest{
+		Id:                      creative.Id,
+		AdAccountId:             adAccountId,
+		Name:                    creative.Name,
+		Type:                    creative.Type,
+		Headline:                optionalValue(creative.Headline, cleared["headline"]),
+		BrandName:               optionalValue(creative.BrandName, cleared["brand_name"]),
+		CallToAction:            optionalValue(creative.CallToAction, cleared["call_to_action"]),
+		Shareable:               optionalValue(creative.Shareable, cleared["shareable"]),
+		TopSnapMediaId:          creative.TopSnapMediaId,
+		TopSnapCropPosition:     creative.TopSnapCropPosition,
+		WebViewProperties:       creative.WebViewProperties,
+		AppInstallProperties:    creative.AppInstallProperties,
+		DeepLinkProperties:      creative.DeepLinkProperties,
+		LongformVideoProperties: creative.LongformVideoProperties,
+		CollectionProperties:    creative.CollectionProperties,
+		PreviewProperties:       creative.PreviewProperties,
+	}, nil
+}
+
+func getCreativesFromResponse(list []*CreativeResponse) []*Creative {
+	var results []*Creative
+	for _, val := range list {
+		if strings.ToLower(val.SubRequestStatus) == "success" {
+			results = append(results, &val.Creative)
+		}
+	}
+	return results
+}
+
+// getCreativesFromBatchResponse returns the successful creatives in a create or update response, along with
+// an ErrBatchFailure describing any creatives that were not successful and wrapping requestErr, the error of the request
+func getCreativesFromBatchResponse(list []*CreativeResponse, requestErr error) ([]*Creative, error) {
+	var results []*Creative
+	batchErr := new(ErrBatchFailure)
+	for i, val := range list {
+		if strings.ToLower(val.SubRequestStatus) == "success" {
+			results = append(results, &val.Creative)
+			continue
+		}
+		batchErr.add(i, val.SubRequestStatus, val.SubRequestErrorReason)
+	}
+	return results, batchErr.wrap(requestErr)
+}
@@ -0,0 +1,213 @@
+package snapchat
+
+// The enumerations below are named string types, so values returned by the api that are not yet known to this
+// package are decoded and sent back unchanged. Valid reports whether a value is one of the documented values
+
+// Status is the status of a campaign, ad squad or ad
+type Status string
+
+const (
+	// StatusActive is the status of an entity that is delivering
+	StatusActive Status = "ACTIVE"
+	// StatusPaused is the status of an entity that is not delivering
+	StatusPaused Status = "PAUSED"
+)
+
+// Valid returns true if the status is one of the documented statuses
+func (status Status) Valid() bool {
+	switch status {
+	case StatusActive, StatusPaused:
+		return true
+	}
+	return false
+}
+
+// Objective is the business objective of a campaign
+type Objective string
+
+const (
+	// ObjectiveBrandAwareness aims to reach as many users as possible
+	ObjectiveBrandAwareness Objective = "BRAND_AWARENESS"
+	// ObjectiveAppInstall aims to get users to install an app
+	ObjectiveAppInstall Objective = "APP_INSTALL"
+	// ObjectiveAppConversion aims to get users to take actions in an app
+	ObjectiveAppConversion Objective = "APP_CONVERSION"
+	// ObjectiveWebConversion aims to get users to take actions on a website
+	ObjectiveWebConversion Objective = "WEB_CONVERSION"
+	// ObjectiveCatalogSales aims to sell products from a catalog
+	ObjectiveCatalogSales Objective = "CATALOG_SALES"
+	// ObjectiveEngagement aims to get users to engage with ads
+	ObjectiveEngagement Objective = "ENGAGEMENT"
+	// ObjectiveLeadGeneration aims to collect information from interested users
+	ObjectiveLeadGeneration Objective = "LEAD_GENERATION"
+	// ObjectiveVideoView aims to get users to watch videos
+	ObjectiveVideoView Objective = "VIDEO_VIEW"
+	// ObjectivePromoteStories aims to get users to open a story
+	ObjectivePromoteStories Objective = "PROMOTE_STORIES"
+	// ObjectivePromotePlaces aims to get users to visit a place
+	ObjectivePromotePlaces Objective = "PROMOTE_PLACES"
+)
+
+// Valid returns true if the objective is one of the documented objectives
+func (objective Objective) Valid() bool {
+	switch objective {
+	case ObjectiveBrandAwareness, ObjectiveAppInstall, ObjectiveAppConversion, ObjectiveWebConversion,
+		ObjectiveCatalogSales, ObjectiveEngagement, ObjectiveLeadGeneration, ObjectiveVideoView,
+		ObjectivePromoteStories, ObjectivePromotePlaces:
+		return true
+	}
+	return false
+}
+
+// BillingEvent is the event an ad squad is billed for
+type BillingEvent string
+
+const (
+	// BillingEventImpression bills for every impression
+	BillingEventImpression BillingEvent = "IMPRESSION"
+)
+
+// Valid returns true if the billing event is one of the documented billing events
+func (event BillingEvent) Valid() bool {
+	switch event {
+	case BillingEventImpression:
+		return true
+	}
+	return false
+}
+
+// OptimizationGoal is the goal an ad squad's delivery is optimized for
+type OptimizationGoal string
+
+const (
+	// OptimizationGoalImpressions optimizes for impressions
+	OptimizationGoalImpressions OptimizationGoal = "IMPRESSIONS"
+	// OptimizationGoalSwipes optimizes for swipe-ups
+	OptimizationGoalSwipes OptimizationGoal = "SWIPES"
+	// OptimizationGoalAppInstalls optimizes for app installs
+	OptimizationGoalAppInstalls OptimizationGoal = "APP_INSTALLS"
+	// OptimizationGoalVideoViews optimizes for video views of at least 2 seconds
+	OptimizationGoalVideoViews OptimizationGoal = "VIDEO_VIEWS"
+	// OptimizationGoalVideoViews15Sec optimizes for video views of at least 15 seconds
+	OptimizationGoalVideoViews15Sec OptimizationGoal = "VIDEO_VIEWS_15_SEC"
+	// OptimizationGoalUses optimizes for lens or filter uses
+	OptimizationGoalUses OptimizationGoal = "USES"
+	// OptimizationGoalStoryOpens optimizes for story ad opens
+	OptimizationGoalStoryOpens OptimizationGoal = "STORY_OPENS"
+	// OptimizationGoalPixelPageView optimizes for pixel page view events
+	OptimizationGoalPixelPageView OptimizationGoal = "PIXEL_PAGE_VIEW"
+	// OptimizationGoalPixelAddToCart optimizes for pixel add to cart events
+	OptimizationGoalPixelAddToCart OptimizationGoal = "PIXEL_ADD_TO_CART"
+	// OptimizationGoalPixelPurchase optimizes for pixel purchase events
+	OptimizationGoalPixelPurchase OptimizationGoal = "PIXEL_PURCHASE"
+	// OptimizationGoalPixelSignup optimizes for pixel sign up events
+	OptimizationGoalPixelSignup OptimizationGoal = "PIXEL_SIGNUP"
+	// OptimizationGoalAppAddToCart optimizes for app add to cart events
+	OptimizationGoalAppAddToCart OptimizationGoal = "APP_ADD_TO_CART"
+	// OptimizationGoalAppPurchase optimizes for app purchase events
+	OptimizationGoalAppPurchase OptimizationGoal = "APP_PURCHASE"
+	// OptimizationGoalAppSignup optimizes for app sign up events
+	OptimizationGoalAppSignup OptimizationGoal = "APP_SIGNUP"
+	// OptimizationGoalAppReengageOpen optimizes for app opens by users who already installed the app
+	OptimizationGoalAppReengageOpen OptimizationGoal = "APP_REENGAGE_OPEN"
+	// OptimizationGoalAppReengagePurchase optimizes for purchases by users who already installed the app
+	OptimizationGoalAppReengagePurchase OptimizationGoal = "APP_REENGAGE_PURCHASE"
+	// OptimizationGoalLandingPageView optimizes for landing page views
+	OptimizationGoalLandingPageView OptimizationGoal = "LANDING_PAGE_VIEW"
+)
+
+// Valid returns true if the optimization goal is one of the documented optimization goals
+func (goal OptimizationGoal) Valid() bool {
+	switch goal {
+	case OptimizationGoalImpressions, OptimizationGoalSwipes, OptimizationGoalAppInstalls, OptimizationGoalVideoViews,
+		OptimizationGoalVideoViews15Sec, OptimizationGoalUses, OptimizationGoalStoryOpens, OptimizationGoalPixelPageView,
+		OptimizationGoalPixelAddToCart, OptimizationGoalPixelPurchase, OptimizationGoalPixelSignup,
+		OptimizationGoalAppAddToCart, OptimizationGoalAppPurchase, OptimizationGoalAppSignup,
+		OptimizationGoalAppReengageOpen, OptimizationGoalAppReengagePurchase, OptimizationGoalLandingPageView:
+		return true
+	}
+	return false
+}
+
+// Placement is where an ad squad's ads are shown
+type Placement string
+
+const (
+	// PlacementSnapAds shows ads between user stories and in discover
+	PlacementSnapAds Placement = "SNAP_ADS"
+	// PlacementContent shows ads only within content from publishers
+	PlacementContent Placement = "CONTENT"
+)
+
+// Valid returns true if the placement is one of the documented placements
+func (placement Placement) Valid() bool {
+	switch placement {
+	case PlacementSnapAds, PlacementContent:
+		return true
+	}
+	return false
+}
+
+// AdType is the type of an ad, which determines what happens when a user swipes up on it
+type AdType string
+
+const (
+	// AdTypeSnapAd is an ad without an attachment
+	AdTypeSnapAd AdType = "SNAP_AD"
+	// AdTypeAppInstall is an ad that leads to an app store page
+	AdTypeAppInstall AdType = "APP_INSTALL"
+	// AdTypeLongformVideo is an ad that leads to a longform video
+	AdTypeLongformVideo AdType = "LONGFORM_VIDEO"
+	// AdTypeRemoteWebpage is an ad that leads to a webpage
+	AdTypeRemoteWebpage AdType = "REMOTE_WEBPAGE"
+	// AdTypeDeepLink is an ad that leads to a deep link in an app
+	AdTypeDeepLink AdType = "DEEP_LINK"
+	// AdTypeStory is a story ad
+	AdTypeStory AdType = "STORY"
+	// AdTypeAdToLens is an ad that leads to a lens
+	AdTypeAdToLens AdType = "AD_TO_LENS"
+	// AdTypeAdToCall is an ad that leads to a phone call
+	AdTypeAdToCall AdType = "AD_TO_CALL"
+	// AdTypeAdToMessage is an ad that leads to a text message
+	AdTypeAdToMessage AdType = "AD_TO_MESSAGE"
+	// AdTypeCollection is a collection ad
+	AdTypeCollection AdType = "COLLECTION"
+	// AdTypeLensRemoteWebpage is a lens ad that leads to a webpage
+	AdTypeLensRemoteWebpage AdType = "LENS_REMOTE_WEB"
+	// AdTypeLensDeepLink is a lens ad that leads to a deep link in an app
+	AdTypeLensDeepLink AdType = "LENS_DEEP_LINK"
+	// AdTypeLensAppInstall is a lens ad that leads to an app store page
+	AdTypeLensAppInstall AdType = "LENS_APP_INSTALL"
+)
+
+// Valid returns true if the ad type is one of the documented ad types
+func (adType AdType) Valid() bool {
+	switch adType {
+	case AdTypeSnapAd, AdTypeAppInstall, AdTypeLongformVideo, AdTypeRemoteWebpage, AdTypeDeepLink, AdTypeStory,
+		AdTypeAdToLens, AdTypeAdToCall, AdTypeAdToMessage, AdTypeCollection, AdTypeLensRemoteWebpage,
+		AdTypeLensDeepLink, AdTypeLensAppInstall:
+		return true
+	}
+	return false
+}
+
+// ReviewStatus is the status of an ad's review process
+type ReviewStatus string
+
+const (
+	// ReviewStatusPending is the review status of an ad that has not been reviewed yet
+	ReviewStatusPending ReviewStatus = "PENDING"
+	// ReviewStatusApproved is the review status of an ad that was approved
+	ReviewStatusApproved ReviewStatus = "APPROVED"
+	// ReviewStatusRejected is the review status of an ad that was rejected, see Ad.ReviewStatusReason
+	ReviewStatusRejected ReviewStatus = "REJECTED"
+)
+
+// Valid returns true if the review status is one of the documented review statuses
+func (status ReviewStatus) Valid() bool {
+	switch status {
+	case ReviewStatusPending, ReviewStatusApproved, ReviewStatusRejected:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,18 @@
+//go:build !unix && !windows
+
+package snapchat
+
+import (
+	"errors"
+	"os"
+)
+
+// tryLockFile is not supported on this platform, FileTokenStore cannot be locked
+func tryLockFile(file *os.File) (bool, error) {
+	return false, errors.New("file locks are not supported on this platform")
+}
+
+// unlockFile is not supported on this platform
+func unlockFile(file *os.File) error {
+	return errors.New("file locks are not supported on this platform")
+}
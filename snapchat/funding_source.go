@@ -19,13 +19,13 @@ type FundingSource struct {
 	// Status is the status of the funding source
 	Status string `json:"status"`
 	// BudgetSpentMicro is the total budget spent (micro-currency)
-	BudgetSpentMicro int64 `json:"budget_spent_micro"`
+	BudgetSpentMicro Micro `json:"budget_spent_micro"`
 	// Currency is the type of currency associated with the funding source
 	Currency string `json:"currency"`
 	// TotalBudgetMicro is the total budget (micro-currency)
-	TotalBudgetMicro int64 `json:"total_budget_micro"`
+	TotalBudgetMicro Micro `json:"total_budget_micro"`
 	// AvailableCreditMicro is the amount of credit available (micro-currency)
-	AvailableCreditMicro int64 `json:"available_credit_micro"`
+	AvailableCreditMicro Micro `json:"available_credit_micro"`
 	// CardType is the type of credit card associated with this funding source
 	CardType string `json:"card_type"`
 	// Name is the name of the funding source
@@ -37,11 +37,11 @@ type FundingSource struct {
 	// ExpirationMonth is the expiration year of the credit card associated with this funding source
 	ExpirationMonth string `json:"expiration_month"`
 	// DailySpendLimitMicro is the daily spend limit for the credit card associated with this funding source (micro-currency)
-	DailySpendLimitMicro int64 `json:"daily_spend_limit_micro"`
+	DailySpendLimitMicro Micro `json:"daily_spend_limit_micro"`
 	// DailySpendLimitMicro is the currency of the  daily spend limit for the credit card associated with this funding source
 	DailySpendLimitCurrency string `json:"daily_spend_limit_currency"`
 	// ValueMicro is the value of the coupon (micro-currency)
-	ValueMicro int64 `json:"value_micro"`
+	ValueMicro Micro `json:"value_micro"`
 	// StartDate is the start date of the coupon
 	StartDate time.Time `json:"start_date"`
 	// EndDate is the end date of the coupon
@@ -59,6 +59,7 @@ type GetFundingSourcesResponse struct {
 	RequestStatus  string                   `json:"request_status"`
 	RequestId      string                   `json:"request_id"`
 	FundingSources []*FundingSourceResponse `json:"fundingsources"`
+	Paging         Paging                   `json:"paging"`
 }
 
 // FundingSourceResponse is the object for a single funding source response
@@ -89,27 +90,43 @@ func (fnd *FundingSourceService) Get(ctx context.Context, fundingSourceId string
 	return nil, fmt.Errorf(`non-success status returned from snapchat api (get funding source with id %s): %s`, fundingSourceId, c.RequestStatus)
 }
 
-// List retrieves all funding sources associated with the specified organization
-func (fnd *FundingSourceService) List(ctx context.Context, organizationId string) ([]*FundingSource, error) {
-	path := fmt.Sprintf(`organizations/%s/funding-sources`, organizationId)
-	req, err := fnd.client.createRequest("GET", path, nil)
+// List retrieves all funding sources associated with the specified organization, following every page of results
+func (fnd *FundingSourceService) List(ctx context.Context, organizationId string, opts ...func(*ListOptions)) ([]*FundingSource, error) {
+	var results []*FundingSource
+	err := collectPages(opts, func(pageOpts []func(*ListOptions)) (string, error) {
+		page, next, err := fnd.ListPage(ctx, organizationId, pageOpts...)
+		results = append(results, page...)
+		return next, err
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	if len(results) > 0 {
+		return results, nil
+	}
+	return nil, fmt.Errorf("no funding sources found for organization id: %s", organizationId)
+}
+
+// ListPage returns a single page of the funding sources associated with the specified organization, along with
+// the cursor to pass to WithPageCursor to get the next page. The cursor is empty on the last page
+func (fnd *FundingSourceService) ListPage(ctx context.Context, organizationId string, opts ...func(*ListOptions)) ([]*FundingSource, string, error) {
+	path := fmt.Sprintf(`organizations/%s/funding-sources`, organizationId)
+	req, err := fnd.client.createListRequest(path, opts)
+	if err != nil {
+		return nil, "", err
+	}
+
 	c := new(GetFundingSourcesResponse)
 	err = fnd.client.do(ctx, req, c)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	if strings.ToLower(c.RequestStatus) == "success" {
-		if len(c.FundingSources) > 0 {
-			return getFundingSourcesFromResponse(c.FundingSources), nil
-		}
-		return nil, fmt.Errorf("no funding sources found for organization id: %s", organizationId)
+		return getFundingSourcesFromResponse(c.FundingSources), c.Paging.cursor(), nil
 	}
-	return nil, fmt.Errorf(`non-success status returned from snapchat api (list funding sources for organization id %s): %s`, organizationId, c.RequestStatus)
+	return nil, "", fmt.Errorf(`non-success status returned from snapchat api (list funding sources for organization id %s): %s`, organizationId, c.RequestStatus)
 }
 
 func getFundingSourcesFromResponse(list []*FundingSourceResponse) []*FundingSource {
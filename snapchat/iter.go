@@ -0,0 +1,121 @@
+//go:build go1.23
+
+package snapchat
+
+import (
+	"context"
+	"iter"
+)
+
+// AllByAdSquad returns an iterator over all of the ads associated with the given ad squad id.
+// Pages are requested as the iterator is consumed, so stopping early skips the remaining pages
+func (ad *AdService) AllByAdSquad(ctx context.Context, adSquadId string, opts ...func(*ListOptions)) iter.Seq2[*Ad, error] {
+	return iteratePages(opts, func(pageOpts []func(*ListOptions)) ([]*Ad, string, error) {
+		return ad.ListByAdSquadPage(ctx, adSquadId, pageOpts...)
+	})
+}
+
+// AllByAdAccount returns an iterator over all of the ads associated with the given ad account id.
+// Pages are requested as the iterator is consumed, so stopping early skips the remaining pages
+func (ad *AdService) AllByAdAccount(ctx context.Context, adAccountId string, opts ...func(*ListOptions)) iter.Seq2[*Ad, error] {
+	return iteratePages(opts, func(pageOpts []func(*ListOptions)) ([]*Ad, string, error) {
+		return ad.ListByAdAccountPage(ctx, adAccountId, pageOpts...)
+	})
+}
+
+// All returns an iterator over all ad accounts associated with the provided organization id.
+// Pages are requested as the iterator is consumed, so stopping early skips the remaining pages
+func (ad *AdAccountService) All(ctx context.Context, organizationId string, opts ...func(*ListOptions)) iter.Seq2[*AdAccount, error] {
+	return iteratePages(opts, func(pageOpts []func(*ListOptions)) ([]*AdAccount, string, error) {
+		return ad.ListPage(ctx, organizationId, pageOpts...)
+	})
+}
+
+// AllByCampaign returns an iterator over all ad squads associated with the specified campaign id.
+// Pages are requested as the iterator is consumed, so stopping early skips the remaining pages
+func (adsqd *AdSquadService) AllByCampaign(ctx context.Context, campaignId string, opts ...func(*ListOptions)) iter.Seq2[*AdSquad, error] {
+	return iteratePages(opts, func(pageOpts []func(*ListOptions)) ([]*AdSquad, string, error) {
+		return adsqd.ListByCampaignPage(ctx, campaignId, pageOpts...)
+	})
+}
+
+// AllByAdAccount returns an iterator over all ad squads associated with the specified ad account id.
+// Pages are requested as the iterator is consumed, so stopping early skips the remaining pages
+func (adsqd *AdSquadService) AllByAdAccount(ctx context.Context, adAccountId string, opts ...func(*ListOptions)) iter.Seq2[*AdSquad, error] {
+	return iteratePages(opts, func(pageOpts []func(*ListOptions)) ([]*AdSquad, string, error) {
+		return adsqd.ListByAdAccountPage(ctx, adAccountId, pageOpts...)
+	})
+}
+
+// All returns an iterator over all campaigns within the specified ad account.
+// Pages are requested as the iterator is consumed, so stopping early skips the remaining pages
+func (cmp *CampaignService) All(ctx context.Context, adAccountId string, opts ...func(*ListOptions)) iter.Seq2[*Campaign, error] {
+	return iteratePages(opts, func(pageOpts []func(*ListOptions)) ([]*Campaign, string, error) {
+		return cmp.ListPage(ctx, adAccountId, pageOpts...)
+	})
+}
+
+// All returns an iterator over all funding sources associated with the specified organization.
+// Pages are requested as the iterator is consumed, so stopping early skips the remaining pages
+func (fnd *FundingSourceService) All(ctx context.Context, organizationId string, opts ...func(*ListOptions)) iter.Seq2[*FundingSource, error] {
+	return iteratePages(opts, func(pageOpts []func(*ListOptions)) ([]*FundingSource, string, error) {
+		return fnd.ListPage(ctx, organizationId, pageOpts...)
+	})
+}
+
+// All returns an iterator over all organizations associated with the authenticated user.
+// Pages are requested as the iterator is consumed, so stopping early skips the remaining pages
+func (org *OrganizationService) All(ctx context.Context, opts ...func(*ListOptions)) iter.Seq2[*Organization, error] {
+	return iteratePages(opts, func(pageOpts []func(*ListOptions)) ([]*Organization, string, error) {
+		return org.ListPage(ctx, pageOpts...)
+	})
+}
+
+// All returns an iterator over all creatives within the specified ad account.
+// Pages are requested as the iterator is consumed, so stopping early skips the remaining pages
+func (crt *CreativeService) All(ctx context.Context, adAccountId string, opts ...func(*ListOptions)) iter.Seq2[*Creative, error] {
+	return iteratePages(opts, func(pageOpts []func(*ListOptions)) ([]*Creative, string, error) {
+		return crt.ListPage(ctx, adAccountId, pageOpts...)
+	})
+}
+
+// All returns an iterator over all media within the specified ad account.
+// Pages are requested as the iterator is consumed, so stopping early skips the remaining pages
+func (med *MediaService) All(ctx context.Context, adAccountId string, opts ...func(*ListOptions)) iter.Seq2[*Media, error] {
+	return iteratePages(opts, func(pageOpts []func(*ListOptions)) ([]*Media, string, error) {
+		return med.ListPage(ctx, adAccountId, pageOpts...)
+	})
+}
+
+// All returns an iterator over all segments within the specified ad account.
+// Pages are requested as the iterator is consumed, so stopping early skips the remaining pages
+func (seg *SegmentService) All(ctx context.Context, adAccountId string, opts ...func(*ListOptions)) iter.Seq2[*Segment, error] {
+	return iteratePages(opts, func(pageOpts []func(*ListOptions)) ([]*Segment, string, error) {
+		return seg.ListPage(ctx, adAccountId, pageOpts...)
+	})
+}
+
+// iteratePages returns an iterator over the entities of every page returned by fetch, starting at the
+// cursor in opts if one is set. Iteration ends after the last page, or after yielding the first error
+func iteratePages[T any](opts []func(*ListOptions), fetch func([]func(*ListOptions)) ([]T, string, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		stopped := false
+		err := collectPages(opts, func(pageOpts []func(*ListOptions)) (string, error) {
+			page, next, err := fetch(pageOpts)
+			if err != nil {
+				return "", err
+			}
+			for _, entity := range page {
+				if !yield(entity, nil) {
+					stopped = true
+					return "", nil
+				}
+			}
+			return next, nil
+		})
+		if err != nil && !stopped {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+//go:build go1.23
+
+package snapchat
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestIteratorFetchesPagesLazily(t *testing.T) {
+	tests := []struct {
+		name        string
+		pageSizes   []int
+		take        int
+		wantIds     []string
+		wantCursors []string
+	}{
+		{"all pages", []int{2, 2, 1}, -1, []string{"0", "1", "2", "3", "4"}, []string{"", "1", "2"}},
+		{"stop within first page", []int{2, 2, 1}, 1, []string{"0"}, []string{""}},
+		{"stop at end of first page", []int{2, 2, 1}, 2, []string{"0", "1"}, []string{""}},
+		{"stop within second page", []int{2, 2, 1}, 3, []string{"0", "1", "2"}, []string{"", "1"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cli, cursors := newPagingServer(t, test.pageSizes...)
+			var ids []string
+			for campaign, err := range cli.Campaigns.All(context.Background(), "account") {
+				if err != nil {
+					t.Fatal(err)
+				}
+				ids = append(ids, campaign.Id)
+				if len(ids) == test.take {
+					break
+				}
+			}
+			if !reflect.DeepEqual(ids, test.wantIds) {
+				t.Errorf("got campaigns %v, want %v", ids, test.wantIds)
+			}
+			if got := cursors(); !reflect.DeepEqual(got, test.wantCursors) {
+				t.Errorf("requested cursors %v, want %v", got, test.wantCursors)
+			}
+		})
+	}
+}
+
+func TestIteratorYieldsErrorOnce(t *testing.T) {
+	failure := errors.New("failed")
+	pages := 0
+	seq := iteratePages(nil, func([]func(*ListOptions)) ([]int, string, error) {
+		pages++
+		if pages == 2 {
+			return nil, "", failure
+		}
+		return []int{1, 2}, "next", nil
+	})
+
+	var values []int
+	var errs []error
+	for value, err := range seq {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		values = append(values, value)
+	}
+	if !reflect.DeepEqual(values, []int{1, 2}) {
+		t.Errorf("got values %v, want [1 2]", values)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], failure) {
+		t.Errorf("got errors %v, want the fetch error once", errs)
+	}
+}
@@ -2,18 +2,255 @@ package snapchat
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/url"
+	"reflect"
 	"strings"
+	"time"
 )
 
 // MeasurementService provides functions for getting snapchat measurement metrics
 type MeasurementService service
 
+// Granularity is the length of the intervals stats are reported in
+type Granularity string
+
+const (
+	// GranularityTotal reports a single total for the requested time range
+	GranularityTotal Granularity = "TOTAL"
+	// GranularityDay reports stats for each day in the requested time range
+	GranularityDay Granularity = "DAY"
+	// GranularityHour reports stats for each hour in the requested time range
+	GranularityHour Granularity = "HOUR"
+	// GranularityLifetime reports a single total for the lifetime of the entity
+	GranularityLifetime Granularity = "LIFETIME"
+)
+
+// Breakdown is the type of child entity stats are broken down by
+type Breakdown string
+
+const (
+	// BreakdownAd breaks stats down by ad
+	BreakdownAd Breakdown = "ad"
+	// BreakdownAdSquad breaks stats down by ad squad
+	BreakdownAdSquad Breakdown = "adsquad"
+	// BreakdownCampaign breaks stats down by campaign
+	BreakdownCampaign Breakdown = "campaign"
+)
+
+// ReportDimension is a dimension, such as geo, demographics or device, stats are reported by
+type ReportDimension string
+
+const (
+	// ReportDimensionCountry reports stats by country
+	ReportDimensionCountry ReportDimension = "country"
+	// ReportDimensionRegion reports stats by region
+	ReportDimensionRegion ReportDimension = "region"
+	// ReportDimensionDma reports stats by designated market area
+	ReportDimensionDma ReportDimension = "dma"
+	// ReportDimensionGender reports stats by gender
+	ReportDimensionGender ReportDimension = "gender"
+	// ReportDimensionAgeBucket reports stats by age bucket
+	ReportDimensionAgeBucket ReportDimension = "age_bucket"
+	// ReportDimensionInterestCategory reports stats by interest category
+	ReportDimensionInterestCategory ReportDimension = "interest_category_id"
+	// ReportDimensionOperatingSystem reports stats by device operating system
+	ReportDimensionOperatingSystem ReportDimension = "operating_system"
+	// ReportDimensionMake reports stats by device make
+	ReportDimensionMake ReportDimension = "make"
+)
+
+// reportDimensions is the set of report dimensions, used to tell dimension values apart from metrics
+var reportDimensions = map[string]bool{
+	string(ReportDimensionCountry):          true,
+	string(ReportDimensionRegion):           true,
+	string(ReportDimensionDma):              true,
+	string(ReportDimensionGender):           true,
+	string(ReportDimensionAgeBucket):        true,
+	string(ReportDimensionInterestCategory): true,
+	string(ReportDimensionOperatingSystem):  true,
+	string(ReportDimensionMake):             true,
+}
+
+// AttributionWindow is the time after an ad interaction in which a conversion is attributed to the ad
+type AttributionWindow string
+
+const (
+	// AttributionWindowNone attributes no conversions, only valid as a view attribution window
+	AttributionWindowNone AttributionWindow = "NONE"
+	// AttributionWindow1Hour attributes conversions within 1 hour, only valid as a view attribution window
+	AttributionWindow1Hour AttributionWindow = "1_HOUR"
+	// AttributionWindow3Hour attributes conversions within 3 hours, only valid as a view attribution window
+	AttributionWindow3Hour AttributionWindow = "3_HOUR"
+	// AttributionWindow6Hour attributes conversions within 6 hours, only valid as a view attribution window
+	AttributionWindow6Hour AttributionWindow = "6_HOUR"
+	// AttributionWindow1Day attributes conversions within 1 day
+	AttributionWindow1Day AttributionWindow = "1_DAY"
+	// AttributionWindow7Day attributes conversions within 7 days
+	AttributionWindow7Day AttributionWindow = "7_DAY"
+	// AttributionWindow28Day attributes conversions within 28 days
+	AttributionWindow28Day AttributionWindow = "28_DAY"
+)
+
+// swipeUpAttributionWindows is the set of attribution windows valid as a swipe up attribution window
+var swipeUpAttributionWindows = map[AttributionWindow]bool{
+	AttributionWindow1Day:  true,
+	AttributionWindow7Day:  true,
+	AttributionWindow28Day: true,
+}
+
+// viewAttributionWindows is the set of attribution windows valid as a view attribution window
+var viewAttributionWindows = map[AttributionWindow]bool{
+	AttributionWindowNone:  true,
+	AttributionWindow1Hour: true,
+	AttributionWindow3Hour: true,
+	AttributionWindow6Hour: true,
+	AttributionWindow1Day:  true,
+	AttributionWindow7Day:  true,
+	AttributionWindow28Day: true,
+}
+
+// ActionReportTime is the time conversions are reported at
+type ActionReportTime string
+
+const (
+	// ActionReportTimeConversion reports conversions at the time the conversion happened
+	ActionReportTimeConversion ActionReportTime = "conversion"
+	// ActionReportTimeImpression reports conversions at the time of the ad impression that led to them
+	ActionReportTimeImpression ActionReportTime = "impression"
+)
+
+// StatsOptions holds the optional parameters used when getting stats
+type StatsOptions struct {
+	// Granularity is the length of the intervals stats are reported in, the api default is used if empty
+	Granularity Granularity
+	// StartTime is the start of the time range to report stats for
+	StartTime time.Time
+	// EndTime is the end of the time range to report stats for
+	EndTime time.Time
+	// Fields is the list of metrics to report, the api default metrics are reported if empty
+	Fields []string
+	// Breakdown is the type of child entity to break stats down by, stats are not broken down if empty
+	Breakdown Breakdown
+	// ReportDimension is the dimension to report stats by, stats are not reported by dimension if empty
+	ReportDimension ReportDimension
+	// SwipeUpAttributionWindow is the attribution window for conversions after a swipe up, the api default is used if empty
+	SwipeUpAttributionWindow AttributionWindow
+	// ViewAttributionWindow is the attribution window for conversions after a view, the api default is used if empty
+	ViewAttributionWindow AttributionWindow
+	// ActionReportTime is the time conversions are reported at, the api default is used if empty
+	ActionReportTime ActionReportTime
+}
+
+// WithGranularity sets the length of the intervals stats are reported in
+func WithGranularity(granularity Granularity) func(*StatsOptions) {
+	return func(opts *StatsOptions) {
+		opts.Granularity = granularity
+	}
+}
+
+// WithFields sets the metrics to report, using their api names (e.g. "impressions", "conversion_purchases_value")
+func WithFields(fields ...string) func(*StatsOptions) {
+	return func(opts *StatsOptions) {
+		opts.Fields = fields
+	}
+}
+
+// WithBreakdown breaks stats down by the given type of child entity, e.g. campaign stats by ad squad or ad.
+// The stats of each child entity are returned in the BreakdownStats of the parent's stats
+func WithBreakdown(breakdown Breakdown) func(*StatsOptions) {
+	return func(opts *StatsOptions) {
+		opts.Breakdown = breakdown
+	}
+}
+
+// WithReportDimension reports stats by the given dimension, e.g. by country or gender.
+// The stats for each value of the dimension are returned in DimensionStats
+func WithReportDimension(dimension ReportDimension) func(*StatsOptions) {
+	return func(opts *StatsOptions) {
+		opts.ReportDimension = dimension
+	}
+}
+
+// WithSwipeUpAttributionWindow sets the attribution window for conversions after a swipe up (1_DAY, 7_DAY, 28_DAY)
+func WithSwipeUpAttributionWindow(window AttributionWindow) func(*StatsOptions) {
+	return func(opts *StatsOptions) {
+		opts.SwipeUpAttributionWindow = window
+	}
+}
+
+// WithViewAttributionWindow sets the attribution window for conversions after a view
+// (NONE, 1_HOUR, 3_HOUR, 6_HOUR, 1_DAY, 7_DAY, 28_DAY)
+func WithViewAttributionWindow(window AttributionWindow) func(*StatsOptions) {
+	return func(opts *StatsOptions) {
+		opts.ViewAttributionWindow = window
+	}
+}
+
+// WithActionReportTime sets whether conversions are reported at the time of the conversion or of the impression
+func WithActionReportTime(reportTime ActionReportTime) func(*StatsOptions) {
+	return func(opts *StatsOptions) {
+		opts.ActionReportTime = reportTime
+	}
+}
+
+// WithTimeRange sets the time range to report stats for. The api requires the boundaries to fall on
+// the start of an hour (HOUR granularity) or day (DAY granularity) in the ad account's timezone
+func WithTimeRange(start, end time.Time) func(*StatsOptions) {
+	return func(opts *StatsOptions) {
+		opts.StartTime = start
+		opts.EndTime = end
+	}
+}
+
+// WithDateRange sets the time range to report stats for to the days from start's date up to, but not including,
+// end's date, with each day starting at midnight in location. Use AdAccount.Location to get the location of the
+// ad account so that days line up with snapchat's reporting, UTC is used if location is nil. The time of day and
+// location of start and end are ignored
+func WithDateRange(start, end time.Time, location *time.Location) func(*StatsOptions) {
+	if location == nil {
+		location = time.UTC
+	}
+	return WithTimeRange(
+		time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, location),
+		time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, location),
+	)
+}
+
 // GetMeasurementsResponse is the response object for get measurement requests
 type GetMeasurementsResponse struct {
-	RequestStatus string        `json:"request_status"` // the status of the request
-	RequestId     string        `json:"request_id"`     // the id of the request
-	TotalStats    []*TotalStats `json:"total_stats"`    // the object containing total stats for this entity
+	RequestStatus   string             `json:"request_status"`   // the status of the request
+	RequestId       string             `json:"request_id"`       // the id of the request
+	TotalStats      []*TotalStats      `json:"total_stats"`      // the object containing total stats for this entity
+	TimeseriesStats []*TimeseriesStats `json:"timeseries_stats"` // the object containing stats for each interval, when requested with DAY or HOUR granularity
+}
+
+// TimeseriesStats is a wrapper object for the timeseries stats response
+type TimeseriesStats struct {
+	SubRequestStatus string         `json:"sub_request_status"`
+	TimeseriesStat   TimeseriesStat `json:"timeseries_stat"`
+}
+
+// TimeseriesStat is an object that contains the metrics for each interval of the requested time range
+type TimeseriesStat struct {
+	Id          string                `json:"id"`          // the id of the entity
+	Type        string                `json:"type"`        // the type of the entity
+	Granularity string                `json:"granularity"` // the length of each interval
+	StartTime   time.Time             `json:"start_time"`  // the start of the time range
+	EndTime     time.Time             `json:"end_time"`    // the end of the time range
+	Timeseries  []*TimeseriesInterval `json:"timeseries"`  // the metrics for each interval
+
+	BreakdownStats map[Breakdown][]*BreakdownStat `json:"breakdown_stats"` // the metrics of each child entity, when requested with a breakdown
+}
+
+// TimeseriesInterval contains the metrics for a single interval of a timeseries
+type TimeseriesInterval struct {
+	StartTime time.Time        `json:"start_time"` // the start of the interval
+	EndTime   time.Time        `json:"end_time"`   // the end of the interval
+	Stats     MeasurementStats `json:"stats"`      // the object containing actual metrics
+
+	DimensionStats []*DimensionStat `json:"dimension_stats"` // the metrics for each dimension value, when requested with a report dimension
 }
 
 // TotalStats is a wrapper object for the stats response
@@ -28,24 +265,343 @@ type TotalStat struct {
 	Type        string           `json:"type"`        // the type of the entity
 	Granularity string           `json:"granularity"` // the level of granulatiry for stats reporting
 	Stats       MeasurementStats `json:"stats"`       // the object containing actual metrics
+
+	BreakdownStats map[Breakdown][]*BreakdownStat `json:"breakdown_stats"` // the metrics of each child entity, when requested with a breakdown
+	DimensionStats []*DimensionStat               `json:"dimension_stats"` // the metrics for each dimension value, when requested with a report dimension
+}
+
+// BreakdownStat contains the metrics of a single child entity when stats are broken down by entity
+type BreakdownStat struct {
+	Id          string                `json:"id"`          // the id of the child entity
+	Type        string                `json:"type"`        // the type of the child entity
+	Granularity string                `json:"granularity"` // the level of granularity for stats reporting
+	StartTime   time.Time             `json:"start_time"`  // the start of the time range
+	EndTime     time.Time             `json:"end_time"`    // the end of the time range
+	Stats       MeasurementStats      `json:"stats"`       // the total metrics, when requested with TOTAL or LIFETIME granularity
+	Timeseries  []*TimeseriesInterval `json:"timeseries"`  // the metrics for each interval, when requested with DAY or HOUR granularity
+
+	DimensionStats []*DimensionStat `json:"dimension_stats"` // the metrics for each dimension value, when requested with a report dimension
+}
+
+// DimensionStat contains the metrics for a single value of a report dimension, e.g. a single country
+type DimensionStat struct {
+	Dimensions map[string]string // the dimension values the metrics are for, keyed by report dimension
+	Stats      MeasurementStats  // the object containing actual metrics
 }
 
-// MeasurementStats is the object actually containing the measurement metrics
+// UnmarshalJSON decodes a dimension stat, where the api returns the dimension values alongside the metrics
+func (stat *DimensionStat) UnmarshalJSON(data []byte) error {
+	var stats MeasurementStats
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return err
+	}
+
+	dimensions := make(map[string]string)
+	for name, raw := range stats.Other {
+		if !reportDimensions[name] {
+			continue
+		}
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			value = string(raw)
+		}
+		dimensions[name] = value
+		delete(stats.Other, name)
+	}
+	if len(stats.Other) == 0 {
+		stats.Other = nil
+	}
+
+	stat.Dimensions = dimensions
+	stat.Stats = stats
+	return nil
+}
+
+// StatsRow is a single row of flattened stats, holding the metrics of one entity, interval and dimension value
+type StatsRow struct {
+	EntityId   string            // the id of the entity the metrics are for
+	EntityType string            // the type of the entity the metrics are for
+	StartTime  time.Time         // the start of the interval, zero for total stats
+	EndTime    time.Time         // the end of the interval, zero for total stats
+	Dimensions map[string]string // the dimension values the metrics are for, nil when not reported by dimension
+	Stats      MeasurementStats  // the metrics
+}
+
+// Rows flattens the stats in the response into one row per entity, interval and dimension value. When stats
+// were broken down by child entity, the rows are those of the child entities rather than the requested entity
+func (resp *GetMeasurementsResponse) Rows() []*StatsRow {
+	var rows []*StatsRow
+	for _, val := range resp.TotalStats {
+		if strings.ToLower(val.SubRequestStatus) != "success" {
+			continue
+		}
+		stat := &val.TotalStat
+		if len(stat.BreakdownStats) > 0 {
+			rows = append(rows, breakdownRows(stat.BreakdownStats)...)
+			continue
+		}
+		rows = append(rows, statsRows(stat.Id, stat.Type, time.Time{}, time.Time{}, stat.Stats, stat.DimensionStats)...)
+	}
+	for _, val := range resp.TimeseriesStats {
+		if strings.ToLower(val.SubRequestStatus) != "success" {
+			continue
+		}
+		stat := &val.TimeseriesStat
+		if len(stat.BreakdownStats) > 0 {
+			rows = append(rows, breakdownRows(stat.BreakdownStats)...)
+			continue
+		}
+		for _, interval := range stat.Timeseries {
+			rows = append(rows, statsRows(stat.Id, stat.Type, interval.StartTime, interval.EndTime, interval.Stats, interval.DimensionStats)...)
+		}
+	}
+	return rows
+}
+
+// breakdownRows flattens the stats of each child entity into rows
+func breakdownRows(breakdowns map[Breakdown][]*BreakdownStat) []*StatsRow {
+	var rows []*StatsRow
+	for _, breakdown := range []Breakdown{BreakdownCampaign, BreakdownAdSquad, BreakdownAd} {
+		for _, stat := range breakdowns[breakdown] {
+			if len(stat.Timeseries) == 0 {
+				rows = append(rows, statsRows(stat.Id, stat.Type, time.Time{}, time.Time{}, stat.Stats, stat.DimensionStats)...)
+				continue
+			}
+			for _, interval := range stat.Timeseries {
+				rows = append(rows, statsRows(stat.Id, stat.Type, interval.StartTime, interval.EndTime, interval.Stats, interval.DimensionStats)...)
+			}
+		}
+	}
+	return rows
+}
+
+// statsRows returns a row for each dimension stat, or a single row holding stats if there are no dimension stats
+func statsRows(id, entityType string, start, end time.Time, stats MeasurementStats, dimensionStats []*DimensionStat) []*StatsRow {
+	if len(dimensionStats) == 0 {
+		return []*StatsRow{{EntityId: id, EntityType: entityType, StartTime: start, EndTime: end, Stats: stats}}
+	}
+	rows := make([]*StatsRow, 0, len(dimensionStats))
+	for _, dim := range dimensionStats {
+		rows = append(rows, &StatsRow{EntityId: id, EntityType: entityType, StartTime: start, EndTime: end, Dimensions: dim.Dimensions, Stats: dim.Stats})
+	}
+	return rows
+}
+
+// MeasurementStats is the object actually containing the measurement metrics. Only the metrics that were
+// requested are set, metrics returned by the api that are not part of this struct are kept in Other
 type MeasurementStats struct {
 	Impressions      int   `json:"impressions"`        // number of impressions
 	Swipes           int   `json:"swipes"`             // number of swipe-ups
-	Spend            int64 `json:"spend"`              // amount spent (micro-currency)
+	Spend            Micro `json:"spend"`              // amount spent (micro-currency)
 	FirstQuartile    int   `json:"quartile_1"`         // number of video views to 25%
 	SecondQuartile   int   `json:"quartile_2"`         // number of video views to 50%
 	ThirdQuartile    int   `json:"quartile_3"`         // number of video views to 75%
 	ScreenTimeMillis int64 `json:"screen_time_millis"` // total time spent on top snap ad (milliseconds)
 	ViewCompletion   int   `json:"view_completion"`    // the number of views to completion
 	VideoViews       int   `json:"video_views"`        // the total number of impressions that meet the qualifying video view criteria of at least 2 seconds of consecutive watch time or a swipe up action on the Top Snap
+
+	// delivery metrics
+	PaidImpressions     int64   `json:"paid_impressions"`       // number of paid impressions
+	EarnedImpressions   int64   `json:"earned_impressions"`     // number of impressions from shares of the ad
+	Uniques             int64   `json:"uniques"`                // number of unique users reached
+	Frequency           float64 `json:"frequency"`              // average number of impressions per unique user
+	Ecpm                Micro   `json:"ecpm"`                   // effective cost per thousand impressions (micro-currency)
+	SwipeUpPercent      float64 `json:"swipe_up_percent"`       // percentage of impressions that resulted in a swipe-up
+	ViewTimeMillis      int64   `json:"view_time_millis"`       // total time spent viewing the ad (milliseconds)
+	AvgScreenTimeMillis float64 `json:"avg_screen_time_millis"` // average time spent on top snap ad (milliseconds)
+	AvgViewTimeMillis   float64 `json:"avg_view_time_millis"`   // average time spent viewing the ad (milliseconds)
+	VideoViewsTimeBased int64   `json:"video_views_time_based"` // number of video views of at least 2 seconds
+	VideoViews15s       int64   `json:"video_views_15s"`        // number of video views of at least 15 seconds or to completion
+	StoryOpens          int64   `json:"story_opens"`            // number of times a story ad was opened
+	StoryCompletes      int64   `json:"story_completes"`        // number of times a story ad was viewed to completion
+	Shares              int64   `json:"shares"`                 // number of times the ad was shared
+	Saves               int64   `json:"saves"`                  // number of times the ad was saved
+
+	// attachment metrics
+	AttachmentUniques             int64   `json:"attachment_uniques"`                // number of unique users that viewed the attachment
+	AttachmentFrequency           float64 `json:"attachment_frequency"`              // average number of attachment views per unique user
+	AttachmentQuartile1           int64   `json:"attachment_quartile_1"`             // number of attachment video views to 25%
+	AttachmentQuartile2           int64   `json:"attachment_quartile_2"`             // number of attachment video views to 50%
+	AttachmentQuartile3           int64   `json:"attachment_quartile_3"`             // number of attachment video views to 75%
+	AttachmentViewCompletion      int64   `json:"attachment_view_completion"`        // number of attachment video views to completion
+	AttachmentVideoViews          int64   `json:"attachment_video_views"`            // number of attachment video views
+	AttachmentTotalViewTimeMillis int64   `json:"attachment_total_view_time_millis"` // total time spent viewing the attachment (milliseconds)
+	AttachmentAvgViewTimeMillis   float64 `json:"attachment_avg_view_time_millis"`   // average time spent viewing the attachment (milliseconds)
+
+	// app install metrics
+	AndroidInstalls int64 `json:"android_installs"` // number of android app installs
+	IosInstalls     int64 `json:"ios_installs"`     // number of ios app installs
+	TotalInstalls   int64 `json:"total_installs"`   // number of app installs across platforms
+
+	// conversion metrics
+	ConversionPurchases           int64   `json:"conversion_purchases"`            // number of purchase events
+	ConversionPurchasesValue      Micro   `json:"conversion_purchases_value"`      // value of purchase events (micro-currency)
+	ConversionAddCart             int64   `json:"conversion_add_cart"`             // number of add to cart events
+	ConversionAddCartValue        Micro   `json:"conversion_add_cart_value"`       // value of add to cart events (micro-currency)
+	ConversionStartCheckout       int64   `json:"conversion_start_checkout"`       // number of start checkout events
+	ConversionStartCheckoutValue  Micro   `json:"conversion_start_checkout_value"` // value of start checkout events (micro-currency)
+	ConversionSubscribe           int64   `json:"conversion_subscribe"`            // number of subscribe events
+	ConversionSubscribeValue      Micro   `json:"conversion_subscribe_value"`      // value of subscribe events (micro-currency)
+	ConversionStartTrial          int64   `json:"conversion_start_trial"`          // number of start trial events
+	ConversionStartTrialValue     Micro   `json:"conversion_start_trial_value"`    // value of start trial events (micro-currency)
+	ConversionSave                int64   `json:"conversion_save"`                 // number of save events
+	ConversionViewContent         int64   `json:"conversion_view_content"`         // number of view content events
+	ConversionAddBilling          int64   `json:"conversion_add_billing"`          // number of add billing events
+	ConversionSignUps             int64   `json:"conversion_sign_ups"`             // number of sign up events
+	ConversionSearches            int64   `json:"conversion_searches"`             // number of search events
+	ConversionLevelCompletes      int64   `json:"conversion_level_completes"`      // number of level complete events
+	ConversionAppOpens            int64   `json:"conversion_app_opens"`            // number of app open events
+	ConversionPageViews           int64   `json:"conversion_page_views"`           // number of page view events
+	ConversionListView            int64   `json:"conversion_list_view"`            // number of list view events
+	ConversionAdClick             int64   `json:"conversion_ad_click"`             // number of ad click events
+	ConversionAdView              int64   `json:"conversion_ad_view"`              // number of ad view events
+	ConversionCompleteTutorial    int64   `json:"conversion_complete_tutorial"`    // number of complete tutorial events
+	ConversionInvite              int64   `json:"conversion_invite"`               // number of invite events
+	ConversionLogin               int64   `json:"conversion_login"`                // number of login events
+	ConversionShare               int64   `json:"conversion_share"`                // number of share events
+	ConversionReserve             int64   `json:"conversion_reserve"`              // number of reserve events
+	ConversionAchievementUnlocked int64   `json:"conversion_achievement_unlocked"` // number of achievement unlocked events
+	ConversionAddToWishlist       int64   `json:"conversion_add_to_wishlist"`      // number of add to wishlist events
+	ConversionSpendCredits        int64   `json:"conversion_spend_credits"`        // number of spend credits events
+	ConversionRate                int64   `json:"conversion_rate"`                 // number of rate events
+	CustomEvent1                  int64   `json:"custom_event_1"`                  // number of custom event 1 events
+	CustomEvent2                  int64   `json:"custom_event_2"`                  // number of custom event 2 events
+	CustomEvent3                  int64   `json:"custom_event_3"`                  // number of custom event 3 events
+	CustomEvent4                  int64   `json:"custom_event_4"`                  // number of custom event 4 events
+	CustomEvent5                  int64   `json:"custom_event_5"`                  // number of custom event 5 events
+	ConversionPurchasesRoas       float64 `json:"conversion_purchases_roas"`       // return on ad spend of purchase events
+
+	Other map[string]json.RawMessage `json:"-"` // metrics returned by the api that are not fields of this struct
+}
+
+// measurementStatsFields is the set of json names of the metrics that are fields of MeasurementStats
+var measurementStatsFields = jsonFieldNames(reflect.TypeOf(MeasurementStats{}))
+
+// UnmarshalJSON decodes the metrics, keeping the metrics that are not fields of MeasurementStats in Other
+func (stats *MeasurementStats) UnmarshalJSON(data []byte) error {
+	type measurementStats MeasurementStats
+	if err := json.Unmarshal(data, (*measurementStats)(stats)); err != nil {
+		return err
+	}
+
+	var metrics map[string]json.RawMessage
+	if err := json.Unmarshal(data, &metrics); err != nil {
+		return err
+	}
+	for name := range metrics {
+		if measurementStatsFields[name] {
+			delete(metrics, name)
+		}
+	}
+	stats.Other = nil
+	if len(metrics) > 0 {
+		stats.Other = metrics
+	}
+	return nil
+}
+
+// jsonFieldNames returns the set of json names of the fields of a struct type
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+	return names
 }
 
 // GetStatsForAdSquad returns the measurement metrics for the given ad squad
-func (measurement *MeasurementService) GetStatsForAdSquad(ctx context.Context, adSquadId string) (*GetMeasurementsResponse, error) {
-	path := fmt.Sprintf(`adsquads/%s/stats`, adSquadId)
+func (measurement *MeasurementService) GetStatsForAdSquad(ctx context.Context, adSquadId string, opts ...func(*StatsOptions)) (*GetMeasurementsResponse, error) {
+	return measurement.getStats(ctx, "adsquads", adSquadId, "ad squad", opts)
+}
+
+// GetStatsForCampaign returns the measurement metrics for the given campaign
+func (measurement *MeasurementService) GetStatsForCampaign(ctx context.Context, campaignId string, opts ...func(*StatsOptions)) (*GetMeasurementsResponse, error) {
+	return measurement.getStats(ctx, "campaigns", campaignId, "campaign", opts)
+}
+
+// GetStatsForAd returns the measurement metrics for the given ad
+func (measurement *MeasurementService) GetStatsForAd(ctx context.Context, adId string, opts ...func(*StatsOptions)) (*GetMeasurementsResponse, error) {
+	return measurement.getStats(ctx, "ads", adId, "ad", opts)
+}
+
+// GetStatsForAdAccount returns the measurement metrics for the given ad account
+func (measurement *MeasurementService) GetStatsForAdAccount(ctx context.Context, adAccountId string, opts ...func(*StatsOptions)) (*GetMeasurementsResponse, error) {
+	return measurement.getStats(ctx, "adaccounts", adAccountId, "ad account", opts)
+}
+
+// statsParams applies the stats options and returns them as query parameters
+func statsParams(optFns []func(*StatsOptions)) (url.Values, error) {
+	opts := new(StatsOptions)
+	for _, fn := range optFns {
+		fn(opts)
+	}
+
+	params := url.Values{}
+	if opts.Granularity != "" {
+		params.Set("granularity", string(opts.Granularity))
+	}
+	if len(opts.Fields) > 0 {
+		params.Set("fields", strings.Join(opts.Fields, ","))
+	}
+	if opts.Breakdown != "" {
+		params.Set("breakdown", string(opts.Breakdown))
+	}
+	if opts.ReportDimension != "" {
+		if !reportDimensions[string(opts.ReportDimension)] {
+			return nil, fmt.Errorf("unknown stats report dimension: %s", opts.ReportDimension)
+		}
+		params.Set("report_dimension", string(opts.ReportDimension))
+	}
+	if opts.SwipeUpAttributionWindow != "" {
+		if !swipeUpAttributionWindows[opts.SwipeUpAttributionWindow] {
+			return nil, fmt.Errorf("invalid swipe up attribution window: %s", opts.SwipeUpAttributionWindow)
+		}
+		params.Set("swipe_up_attribution_window", string(opts.SwipeUpAttributionWindow))
+	}
+	if opts.ViewAttributionWindow != "" {
+		if !viewAttributionWindows[opts.ViewAttributionWindow] {
+			return nil, fmt.Errorf("invalid view attribution window: %s", opts.ViewAttributionWindow)
+		}
+		params.Set("view_attribution_window", string(opts.ViewAttributionWindow))
+	}
+	switch opts.ActionReportTime {
+	case "":
+	case ActionReportTimeConversion, ActionReportTimeImpression:
+		params.Set("action_report_time", string(opts.ActionReportTime))
+	default:
+		return nil, fmt.Errorf("invalid action report time: %s", opts.ActionReportTime)
+	}
+	if opts.StartTime.IsZero() != opts.EndTime.IsZero() {
+		return nil, fmt.Errorf("stats time range requires both a start and an end time")
+	}
+	if !opts.StartTime.IsZero() {
+		if !opts.StartTime.Before(opts.EndTime) {
+			return nil, fmt.Errorf("stats start time %s is not before end time %s", opts.StartTime, opts.EndTime)
+		}
+		params.Set("start_time", opts.StartTime.Format(time.RFC3339))
+		params.Set("end_time", opts.EndTime.Format(time.RFC3339))
+	}
+	if (opts.Granularity == GranularityDay || opts.Granularity == GranularityHour) && opts.StartTime.IsZero() {
+		return nil, fmt.Errorf("%s granularity requires a time range", opts.Granularity)
+	}
+	return params, nil
+}
+
+// getStats returns the measurement metrics for the entity with the given id, entityPath is the api path
+// of the entity's type and entityName is used to describe it in errors
+func (measurement *MeasurementService) getStats(ctx context.Context, entityPath, entityId, entityName string, optFns []func(*StatsOptions)) (*GetMeasurementsResponse, error) {
+	params, err := statsParams(optFns)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf(`%s/%s/stats`, entityPath, entityId)
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
 	req, err := measurement.client.createRequest("GET", path, nil)
 	if err != nil {
 		return nil, err
@@ -59,5 +615,5 @@ func (measurement *MeasurementService) GetStatsForAdSquad(ctx context.Context, a
 	if strings.ToLower(m.RequestStatus) == "success" {
 		return m, nil
 	}
-	return nil, fmt.Errorf(`non-success status returned from snapchat api (get stats for ad squad with id %s): %s`, adSquadId, m.RequestStatus)
+	return nil, fmt.Errorf(`non-success status returned from snapchat api (get stats for %s with id %s): %s`, entityName, entityId, m.RequestStatus)
 }
@@ -0,0 +1,39 @@
+package snapchat
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWithDateRange(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("timezone database not available")
+	}
+	start := time.Date(2024, 3, 9, 15, 30, 0, 0, time.UTC)
+	end := time.Date(2024, 3, 11, 1, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		location  *time.Location
+		wantStart string
+		wantEnd   string
+	}{
+		{"nil location", nil, "2024-03-09T00:00:00Z", "2024-03-11T00:00:00Z"},
+		{"utc", time.UTC, "2024-03-09T00:00:00Z", "2024-03-11T00:00:00Z"},
+		{"ad account timezone", newYork, "2024-03-09T00:00:00-05:00", "2024-03-11T00:00:00-04:00"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			params, err := statsParams([]func(*StatsOptions){WithDateRange(start, end, test.location)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := params.Get("start_time"); got != test.wantStart {
+				t.Errorf("got start time %s, want %s", got, test.wantStart)
+			}
+			if got := params.Get("end_time"); got != test.wantEnd {
+				t.Errorf("got end time %s, want %s", got, test.wantEnd)
+			}
+		})
+	}
+}
@@ -0,0 +1,423 @@
+package snapchat
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxSingleUploadSize is the largest file that is uploaded in a single request, larger files are uploaded in chunks
+	MaxSingleUploadSize = 32 << 20
+	// DefaultUploadChunkSize is the size of each chunk when uploading a file in chunks
+	DefaultUploadChunkSize = 5 << 20
+	// DefaultUploadPollInterval is the first delay between checks of whether uploaded media is READY
+	DefaultUploadPollInterval = defaultPollInterval
+)
+
+// failedMediaStatuses are the statuses of media whose uploaded file could not be processed, which will not
+// become READY
+var failedMediaStatuses = map[string]bool{
+	"FAILED":  true,
+	"INVALID": true,
+	"ERROR":   true,
+}
+
+// MediaService provides functions for interacting with snapchat media
+type MediaService service
+
+// Media represents a media file, such as a video or image, in the snapchat ads api
+type Media struct {
+	// Id is the id representing a single media
+	Id string `json:"id"`
+	// AdAccountId is the id of the ad account the media is under
+	AdAccountId string `json:"ad_account_id"`
+	// Name is the name of the media
+	Name string `json:"name"`
+	// Type is the type of the media (VIDEO, IMAGE)
+	Type string `json:"type"`
+	// MediaStatus is the upload status of the media (PENDING_UPLOAD, READY, FAILED, INVALID)
+	MediaStatus string `json:"media_status"`
+	// FileName is the name of the uploaded file
+	FileName string `json:"file_name"`
+	// DownloadLink is a link to download the uploaded file
+	DownloadLink string `json:"download_link"`
+	// DurationInSeconds is the duration of a video
+	DurationInSeconds float64 `json:"duration_in_seconds"`
+	// CreatedAt is the time when the media was created
+	CreatedAt time.Time `json:"created_at"`
+	// UpdatedAt is the time when the media was last updated
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// GetMediaResponse is the response object returned when getting media
+type GetMediaResponse struct {
+	RequestStatus string           `json:"request_status"`
+	RequestId     string           `json:"request_id"`
+	Media         []*MediaResponse `json:"media"`
+	Paging        Paging           `json:"paging"`
+}
+
+// MediaResponse is the object for a single media response
+type MediaResponse struct {
+	SubRequestStatus      string `json:"sub_request_status"`
+	SubRequestErrorReason string `json:"sub_request_error_reason"`
+	Media                 Media  `json:"media"`
+}
+
+// mediaRequest is the request object used when creating media
+type mediaRequest struct {
+	Media []*mediaCreateRequest `json:"media"`
+}
+
+// mediaCreateRequest contains the media fields that can be sent when creating media
+type mediaCreateRequest struct {
+	AdAccountId string `json:"ad_account_id"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+}
+
+// uploadResponse is the response object returned by upload requests
+type uploadResponse struct {
+	RequestStatus string `json:"request_status"`
+	RequestId     string `json:"request_id"`
+}
+
+// status returns the request status of the upload response
+func (resp *uploadResponse) status() string {
+	return resp.RequestStatus
+}
+
+// chunkedUploadResponse is the response object returned when starting a chunked upload
+type chunkedUploadResponse struct {
+	uploadResponse
+	UploadId     string `json:"upload_id"`
+	AddPath      string `json:"add_path"`
+	FinalizePath string `json:"finalize_path"`
+}
+
+// UploadOptions holds the optional parameters used when uploading a file
+type UploadOptions struct {
+	// FileName is the name of the uploaded file
+	FileName string
+	// ChunkSize is the size of each chunk when the file is uploaded in chunks
+	ChunkSize int64
+	// Progress is called after each chunk is uploaded with the number of bytes uploaded so far and the total size
+	Progress func(uploaded, total int64)
+	// PollInterval is the first delay between checks of whether the media is READY after the upload
+	PollInterval time.Duration
+}
+
+// WithUploadFileName sets the name of the uploaded file
+func WithUploadFileName(fileName string) func(*UploadOptions) {
+	return func(opts *UploadOptions) {
+		opts.FileName = fileName
+	}
+}
+
+// WithUploadChunkSize sets the size of each chunk when a file is uploaded in chunks
+func WithUploadChunkSize(chunkSize int64) func(*UploadOptions) {
+	return func(opts *UploadOptions) {
+		opts.ChunkSize = chunkSize
+	}
+}
+
+// WithUploadProgress sets a function that is called after each chunk of a file is uploaded
+func WithUploadProgress(progress func(uploaded, total int64)) func(*UploadOptions) {
+	return func(opts *UploadOptions) {
+		opts.Progress = progress
+	}
+}
+
+// WithUploadPollInterval sets the first delay between checks of whether the media is READY after the upload,
+// the delay doubles with every check
+func WithUploadPollInterval(interval time.Duration) func(*UploadOptions) {
+	return func(opts *UploadOptions) {
+		opts.PollInterval = interval
+	}
+}
+
+// Get retrieves a specific media
+func (med *MediaService) Get(ctx context.Context, mediaId string) (*Media, error) {
+	path := fmt.Sprintf(`media/%s`, mediaId)
+	req, err := med.client.createRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	m := new(GetMediaResponse)
+	err = med.client.do(ctx, req, m)
+	if err != nil {
+		return nil, err
+	}
+	if strings.ToLower(m.RequestStatus) == "success" {
+		if len(m.Media) > 0 {
+			return &m.Media[0].Media, nil
+		}
+		return nil, fmt.Errorf("no media found with media id: %s", mediaId)
+	}
+	return nil, fmt.Errorf(`non-success status returned from snapchat api (get media with id %s): %s`, mediaId, m.RequestStatus)
+}
+
+// List retrieves all media within a specified ad account, following every page of results
+func (med *MediaService) List(ctx context.Context, adAccountId string, opts ...func(*ListOptions)) ([]*Media, error) {
+	var results []*Media
+	err := collectPages(opts, func(pageOpts []func(*ListOptions)) (string, error) {
+		page, next, err := med.ListPage(ctx, adAccountId, pageOpts...)
+		results = append(results, page...)
+		return next, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) > 0 {
+		return results, nil
+	}
+	return nil, fmt.Errorf("no media found for ad account id: %s", adAccountId)
+}
+
+// ListPage returns a single page of the media within a specified ad account, along with
+// the cursor to pass to WithPageCursor to get the next page. The cursor is empty on the last page
+func (med *MediaService) ListPage(ctx context.Context, adAccountId string, opts ...func(*ListOptions)) ([]*Media, string, error) {
+	path := fmt.Sprintf(`adaccounts/%s/media`, adAccountId)
+	req, err := med.client.createListRequest(path, opts)
+	if err != nil {
+		return nil, "", err
+	}
+
+	m := new(GetMediaResponse)
+	err = med.client.do(ctx, req, m)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if strings.ToLower(m.RequestStatus) == "success" {
+		return getMediaFromResponse(m.Media), m.Paging.cursor(), nil
+	}
+	return nil, "", fmt.Errorf(`non-success status returned from snapchat api (list media for ad account with id %s): %s`, adAccountId, m.RequestStatus)
+}
+
+// Create creates the provided media entities within the specified ad account and returns them as created by
+// the api, including the ids to upload files to. Only the name and type of the provided media are sent.
+// If some of the media could not be created the others are still returned along with an ErrBatchFailure
+func (med *MediaService) Create(ctx context.Context, adAccountId string, media ...*Media) ([]*Media, error) {
+	if len(media) == 0 {
+		return nil, fmt.Errorf("no media provided")
+	}
+	body := new(mediaRequest)
+	for _, m := range media {
+		body.Media = append(body.Media, &mediaCreateRequest{
+			AdAccountId: adAccountId,
+			Name:        m.Name,
+			Type:        m.Type,
+		})
+	}
+
+	path := fmt.Sprintf(`adaccounts/%s/media`, adAccountId)
+	req, err := med.client.createRequest("POST", path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	m := new(GetMediaResponse)
+	err = med.client.do(ctx, req, m)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(m.Media) > 0 {
+		return getMediaFromBatchResponse(m.Media)
+	}
+	return nil, fmt.Errorf(`non-success status returned from snapchat api (create media for ad account with id %s): %s`, adAccountId, m.RequestStatus)
+}
+
+// Upload uploads size bytes read from r as the file of a specific media, and returns the media once the
+// api reports it as READY, see WaitUntilReady. Files up to MaxSingleUploadSize are uploaded in a single request,
+// larger files are uploaded in chunks, and a chunk that fails is sent again according to the client's retry policy
+func (med *MediaService) Upload(ctx context.Context, mediaId string, r io.Reader, size int64, optFns ...func(*UploadOptions)) (*Media, error) {
+	opts := &UploadOptions{
+		FileName:     mediaId,
+		ChunkSize:    DefaultUploadChunkSize,
+		PollInterval: DefaultUploadPollInterval,
+	}
+	for _, fn := range optFns {
+		fn(opts)
+	}
+	if size <= 0 {
+		return nil, fmt.Errorf("upload size must be positive, got %d", size)
+	}
+	if opts.ChunkSize <= 0 {
+		return nil, fmt.Errorf("upload chunk size must be positive, got %d", opts.ChunkSize)
+	}
+
+	var err error
+	if size <= MaxSingleUploadSize {
+		err = med.uploadSingle(ctx, mediaId, r, size, opts)
+	} else {
+		err = med.uploadChunked(ctx, mediaId, r, size, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return med.WaitUntilReady(ctx, mediaId, opts.PollInterval)
+}
+
+// WaitUntilReady polls a specific media until the api has processed its uploaded file and reports it as READY,
+// and returns it. The delay between polls starts at interval and doubles with every poll, up to 30 seconds.
+// Large videos can take minutes to process, it returns an error along with the media as of the latest poll once
+// the context is done, or if the api could not process the file
+func (med *MediaService) WaitUntilReady(ctx context.Context, mediaId string, interval time.Duration) (*Media, error) {
+	var m *Media
+	err := poll(ctx, interval, func() (bool, error) {
+		current, err := med.Get(ctx, mediaId)
+		if err != nil {
+			return false, err
+		}
+		m = current
+		if failedMediaStatuses[m.MediaStatus] {
+			return false, fmt.Errorf("media with id %s could not be processed: %s", mediaId, m.MediaStatus)
+		}
+		return m.MediaStatus == "READY", nil
+	})
+	return m, err
+}
+
+// uploadSingle uploads a file in a single request
+func (med *MediaService) uploadSingle(ctx context.Context, mediaId string, r io.Reader, size int64, opts *UploadOptions) error {
+	file, err := readChunk(r, size)
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf(`media/%s/upload`, mediaId)
+	err = med.sendUploadRequest(ctx, path, nil, opts.FileName, file, new(uploadResponse))
+	if err != nil {
+		return err
+	}
+	if opts.Progress != nil {
+		opts.Progress(size, size)
+	}
+	return nil
+}
+
+// uploadChunked uploads a file in chunks of opts.ChunkSize using the init, add and finalize upload flow
+func (med *MediaService) uploadChunked(ctx context.Context, mediaId string, r io.Reader, size int64, opts *UploadOptions) error {
+	parts := (size + opts.ChunkSize - 1) / opts.ChunkSize
+	path := fmt.Sprintf(`media/%s/multipart-upload-v2?action=INIT`, mediaId)
+	fields := map[string]string{
+		"file_name":       opts.FileName,
+		"file_size":       strconv.FormatInt(size, 10),
+		"number_of_parts": strconv.FormatInt(parts, 10),
+	}
+	upload := new(chunkedUploadResponse)
+	err := med.sendUploadRequest(ctx, path, fields, "", nil, upload)
+	if err != nil {
+		return err
+	}
+	if upload.UploadId == "" || upload.AddPath == "" || upload.FinalizePath == "" {
+		return fmt.Errorf("incomplete chunked upload response for media with id %s", mediaId)
+	}
+
+	var uploaded int64
+	for part := int64(1); part <= parts; part++ {
+		chunkSize := opts.ChunkSize
+		if remaining := size - uploaded; remaining < chunkSize {
+			chunkSize = remaining
+		}
+		chunk, err := readChunk(r, chunkSize)
+		if err != nil {
+			return err
+		}
+
+		fields := map[string]string{
+			"upload_id":   upload.UploadId,
+			"part_number": strconv.FormatInt(part, 10),
+		}
+		err = med.sendUploadRequest(ctx, upload.AddPath, fields, opts.FileName, chunk, new(uploadResponse))
+		if err != nil {
+			return fmt.Errorf("upload part %d of %d for media with id %s: %w", part, parts, mediaId, err)
+		}
+
+		uploaded += chunkSize
+		if opts.Progress != nil {
+			opts.Progress(uploaded, size)
+		}
+	}
+
+	fields = map[string]string{"upload_id": upload.UploadId}
+	return med.sendUploadRequest(ctx, upload.FinalizePath, fields, "", nil, new(uploadResponse))
+}
+
+// sendUploadRequest sends a multipart upload request, sending it again according to the client's retry policy
+// if it fails with a retryable error, it is sent once if the client has no retry policy. Upload requests are not
+// idempotent, so they are retried here rather than in do
+func (med *MediaService) sendUploadRequest(ctx context.Context, path string, fields map[string]string, fileName string, file []byte, target interface{ status() string }) error {
+	policy := med.client.retryPolicy
+
+	for attempt := 1; ; attempt++ {
+		var body io.Reader
+		if file != nil {
+			body = bytes.NewReader(file)
+		}
+		req, err := med.client.createMultipartRequest(path, fields, fileName, body)
+		if err != nil {
+			return err
+		}
+
+		err = med.client.do(ctx, req, target)
+		if err == nil {
+			if strings.ToLower(target.status()) == "success" {
+				return nil
+			}
+			return fmt.Errorf(`non-success status returned from snapchat api (upload media): %s`, target.status())
+		}
+		var apiErr *APIError
+		if policy == nil || !errors.As(err, &apiErr) || !isRetryableStatusCode(apiErr.StatusCode) || attempt >= policy.MaxAttempts {
+			return err
+		}
+		if sleepErr := sleep(ctx, policy.delay(attempt, nil)); sleepErr != nil {
+			return &ErrRetryFailed{Attempts: attempt, Err: err, ContextErr: sleepErr}
+		}
+	}
+}
+
+// readChunk reads exactly size bytes from r
+func readChunk(r io.Reader, size int64) ([]byte, error) {
+	chunk := make([]byte, size)
+	if _, err := io.ReadFull(r, chunk); err != nil {
+		return nil, fmt.Errorf("read %d bytes of upload: %w", size, err)
+	}
+	return chunk, nil
+}
+
+func getMediaFromResponse(list []*MediaResponse) []*Media {
+	var results []*Media
+	for _, val := range list {
+		if strings.ToLower(val.SubRequestStatus) == "success" {
+			results = append(results, &val.Media)
+		}
+	}
+	return results
+}
+
+// getMediaFromBatchResponse returns the successful media in a create response, along with
+// an ErrBatchFailure describing any media that were not successful
+func getMediaFromBatchResponse(list []*MediaResponse) ([]*Media, error) {
+	var results []*Media
+	batchErr := new(ErrBatchFailure)
+	for i, val := range list {
+		if strings.ToLower(val.SubRequestStatus) == "success" {
+			results = append(results, &val.Media)
+			continue
+		}
+		batchErr.add(i, val.SubRequestStatus, val.SubRequestErrorReason)
+	}
+	return results, batchErr.errOrNil()
+}
@@ -0,0 +1,74 @@
+package snapchat
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newMediaTestServer returns a client for a server that reports the given statuses for media "media", one per
+// poll, and fails uploads with a 503, along with the number of upload requests received
+func newMediaTestServer(t *testing.T, statuses ...string) (*Client, *int32) {
+	t.Helper()
+	var polls, uploads int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/upload") {
+			atomic.AddInt32(&uploads, 1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		status := statuses[len(statuses)-1]
+		if poll := int(atomic.AddInt32(&polls, 1)); poll <= len(statuses) {
+			status = statuses[poll-1]
+		}
+		fmt.Fprintf(w, `{"request_status":"SUCCESS","media":[{"sub_request_status":"SUCCESS","media":{"id":"media","media_status":%q}}]}`, status)
+	}))
+	t.Cleanup(server.Close)
+	cli, err := NewClient(WithHost(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cli, &uploads
+}
+
+func TestWaitUntilReady(t *testing.T) {
+	tests := []struct {
+		name       string
+		statuses   []string
+		wantStatus string
+		wantErr    bool
+	}{
+		{"ready", []string{"READY"}, "READY", false},
+		{"ready after processing", []string{"PENDING_UPLOAD", "PENDING_UPLOAD", "READY"}, "READY", false},
+		{"failed", []string{"PENDING_UPLOAD", "FAILED"}, "FAILED", true},
+		{"invalid", []string{"INVALID"}, "INVALID", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cli, _ := newMediaTestServer(t, test.statuses...)
+			m, err := cli.Media.WaitUntilReady(context.Background(), "media", time.Millisecond)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got error %v, want error %t", err, test.wantErr)
+			}
+			if m == nil || m.MediaStatus != test.wantStatus {
+				t.Errorf("got media %+v, want status %s", m, test.wantStatus)
+			}
+		})
+	}
+}
+
+func TestUploadNotRetriedWithoutRetryPolicy(t *testing.T) {
+	cli, uploads := newMediaTestServer(t, "READY")
+	_, err := cli.Media.Upload(context.Background(), "media", strings.NewReader("file"), 4)
+	if err == nil {
+		t.Fatal("expected the upload to fail")
+	}
+	if *uploads != 1 {
+		t.Errorf("sent %d upload requests, want 1", *uploads)
+	}
+}
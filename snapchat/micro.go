@@ -0,0 +1,187 @@
+package snapchat
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// MicrosPerUnit is the number of micro-currency units in a single unit of currency, e.g. in one dollar
+const MicrosPerUnit = 1000000
+
+// ErrMicroOverflow is returned when an amount does not fit in a Micro
+var ErrMicroOverflow = errors.New("micro-currency amount overflows int64")
+
+// currencyDecimals maps currency codes to the number of decimals they are formatted with, currencies
+// that are not listed are formatted with two decimals
+var currencyDecimals = map[string]int{
+	"BHD": 3,
+	"CLP": 0,
+	"IQD": 3,
+	"ISK": 0,
+	"JOD": 3,
+	"JPY": 0,
+	"KRW": 0,
+	"KWD": 3,
+	"LYD": 3,
+	"OMR": 3,
+	"PYG": 0,
+	"TND": 3,
+	"UGX": 0,
+	"VND": 0,
+}
+
+// currencySymbols maps currency codes to the symbol they are formatted with, currencies that are not
+// listed are formatted with their code
+var currencySymbols = map[string]string{
+	"AUD": "A$",
+	"CAD": "CA$",
+	"EUR": "€",
+	"GBP": "£",
+	"INR": "₹",
+	"JPY": "¥",
+	"KRW": "₩",
+	"USD": "$",
+}
+
+// Micro is an amount of money in micro-currency, where MicrosPerUnit micros make up a single unit of the currency.
+// It is encoded in json as an integer, the same as the api's *_micro fields
+type Micro int64
+
+// ParseMicro parses a decimal amount of currency units, such as "12.5" or "-0.000001", into micro-currency.
+// Amounts with more than six decimals cannot be represented and return an error
+func ParseMicro(amount string) (Micro, error) {
+	value := strings.TrimSpace(amount)
+	negative := strings.HasPrefix(value, "-")
+	value = strings.TrimPrefix(strings.TrimPrefix(value, "-"), "+")
+
+	whole, fraction := value, ""
+	if i := strings.IndexByte(value, '.'); i >= 0 {
+		whole, fraction = value[:i], value[i+1:]
+	}
+	if whole == "" && fraction == "" || !isDigits(whole) || !isDigits(fraction) {
+		return 0, fmt.Errorf("invalid currency amount: %q", amount)
+	}
+	fraction = strings.TrimRight(fraction, "0")
+	if len(fraction) > 6 {
+		return 0, fmt.Errorf("currency amount %q has more than 6 decimals", amount)
+	}
+
+	var units, micros int64
+	var err error
+	if whole != "" {
+		if units, err = strconv.ParseInt(whole, 10, 64); err != nil {
+			return 0, fmt.Errorf("parse currency amount %q: %w", amount, ErrMicroOverflow)
+		}
+	}
+	if fraction != "" {
+		micros, _ = strconv.ParseInt(fraction+strings.Repeat("0", 6-len(fraction)), 10, 64)
+	}
+
+	m, err := Micro(units).Mul(MicrosPerUnit)
+	if err == nil {
+		m, err = m.Add(Micro(micros))
+	}
+	if err != nil {
+		return 0, fmt.Errorf("parse currency amount %q: %w", amount, err)
+	}
+	if negative {
+		m = -m
+	}
+	return m, nil
+}
+
+// String returns the amount as a decimal number of currency units, without trailing zeros, e.g. "12.5"
+func (m Micro) String() string {
+	sign := ""
+	abs := uint64(m)
+	if m < 0 {
+		sign = "-"
+		abs = uint64(-m)
+	}
+	whole := abs / MicrosPerUnit
+	fraction := strings.TrimRight(fmt.Sprintf("%06d", abs%MicrosPerUnit), "0")
+	if fraction == "" {
+		return fmt.Sprintf("%s%d", sign, whole)
+	}
+	return fmt.Sprintf("%s%d.%s", sign, whole, fraction)
+}
+
+// Format returns the amount formatted for the given three letter currency code, rounded half away from zero
+// to the number of decimals the currency uses, e.g. "$12.35" for USD or "¥1235" for JPY
+func (m Micro) Format(currency string) string {
+	currency = strings.ToUpper(currency)
+	decimals, ok := currencyDecimals[currency]
+	if !ok {
+		decimals = 2
+	}
+
+	sign := ""
+	abs := uint64(m)
+	if m < 0 {
+		sign = "-"
+		abs = uint64(-m)
+	}
+	step := uint64(math.Pow10(6 - decimals))
+	abs = (abs + step/2) / step
+	if abs == 0 {
+		sign = ""
+	}
+	amount := strconv.FormatUint(abs, 10)
+	if decimals > 0 {
+		if len(amount) <= decimals {
+			amount = strings.Repeat("0", decimals-len(amount)+1) + amount
+		}
+		amount = amount[:len(amount)-decimals] + "." + amount[len(amount)-decimals:]
+	}
+
+	if symbol, ok := currencySymbols[currency]; ok {
+		return sign + symbol + amount
+	}
+	if currency == "" {
+		return sign + amount
+	}
+	return sign + amount + " " + currency
+}
+
+// Add returns the sum of m and other, or ErrMicroOverflow if it does not fit in a Micro
+func (m Micro) Add(other Micro) (Micro, error) {
+	sum := m + other
+	if (other > 0 && sum < m) || (other < 0 && sum > m) {
+		return 0, ErrMicroOverflow
+	}
+	return sum, nil
+}
+
+// Sub returns the difference of m and other, or ErrMicroOverflow if it does not fit in a Micro
+func (m Micro) Sub(other Micro) (Micro, error) {
+	diff := m - other
+	if (other > 0 && diff > m) || (other < 0 && diff < m) {
+		return 0, ErrMicroOverflow
+	}
+	return diff, nil
+}
+
+// Mul returns m multiplied by n, or ErrMicroOverflow if it does not fit in a Micro
+func (m Micro) Mul(n int64) (Micro, error) {
+	if m == 0 || n == 0 {
+		return 0, nil
+	}
+	product := m * Micro(n)
+	if product/Micro(n) != m || (m == -1 && n == math.MinInt64) || (n == -1 && m == math.MinInt64) {
+		return 0, ErrMicroOverflow
+	}
+	return product, nil
+}
+
+// isDigits returns true if s only contains ascii digits
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,112 @@
+package snapchat
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParseMicro(t *testing.T) {
+	tests := []struct {
+		amount  string
+		want    Micro
+		wantErr error
+	}{
+		{"12.5", 12500000, nil},
+		{"0.000001", 1, nil},
+		{"-0.000001", -1, nil},
+		{"+3", 3000000, nil},
+		{" 7.10 ", 7100000, nil},
+		{".5", 500000, nil},
+		{"5.", 5000000, nil},
+		{"1.1234560", 1123456, nil},
+		{"9223372036854.775807", math.MaxInt64, nil},
+		{"9223372036854.775808", 0, ErrMicroOverflow},
+		{"99999999999999999999", 0, ErrMicroOverflow},
+		{"1.0000001", 0, errors.New("")},
+		{"", 0, errors.New("")},
+		{".", 0, errors.New("")},
+		{"1,5", 0, errors.New("")},
+		{"--1", 0, errors.New("")},
+		{"1e6", 0, errors.New("")},
+	}
+	for _, test := range tests {
+		got, err := ParseMicro(test.amount)
+		if got != test.want || (err == nil) != (test.wantErr == nil) {
+			t.Errorf("ParseMicro(%q) = %d, %v, want %d, error %v", test.amount, got, err, test.want, test.wantErr)
+		}
+		if test.wantErr == ErrMicroOverflow && !errors.Is(err, ErrMicroOverflow) {
+			t.Errorf("ParseMicro(%q) returned %v, want ErrMicroOverflow", test.amount, err)
+		}
+	}
+}
+
+func TestMicroString(t *testing.T) {
+	tests := []struct {
+		m    Micro
+		want string
+	}{
+		{0, "0"},
+		{12500000, "12.5"},
+		{1, "0.000001"},
+		{-1500000, "-1.5"},
+		{math.MinInt64, "-9223372036854.775808"},
+	}
+	for _, test := range tests {
+		if got := test.m.String(); got != test.want {
+			t.Errorf("Micro(%d).String() = %q, want %q", int64(test.m), got, test.want)
+		}
+	}
+}
+
+func TestMicroFormat(t *testing.T) {
+	tests := []struct {
+		m        Micro
+		currency string
+		want     string
+	}{
+		{12345000, "USD", "$12.35"},
+		{12344999, "usd", "$12.34"},
+		{-12345000, "EUR", "-€12.35"},
+		{1234500000, "JPY", "¥1235"},
+		{1234500, "KWD", "1.235 KWD"},
+		{5000, "CHF", "0.01 CHF"},
+		{-4000, "USD", "$0.00"},
+		{1500000, "", "1.50"},
+	}
+	for _, test := range tests {
+		if got := test.m.Format(test.currency); got != test.want {
+			t.Errorf("Micro(%d).Format(%q) = %q, want %q", int64(test.m), test.currency, got, test.want)
+		}
+	}
+}
+
+func TestMicroArithmeticOverflow(t *testing.T) {
+	tests := []struct {
+		name    string
+		op      func() (Micro, error)
+		want    Micro
+		wantErr bool
+	}{
+		{"add", func() (Micro, error) { return Micro(1).Add(2) }, 3, false},
+		{"add overflow", func() (Micro, error) { return Micro(math.MaxInt64).Add(1) }, 0, true},
+		{"add underflow", func() (Micro, error) { return Micro(math.MinInt64).Add(-1) }, 0, true},
+		{"sub", func() (Micro, error) { return Micro(1).Sub(3) }, -2, false},
+		{"sub overflow", func() (Micro, error) { return Micro(math.MaxInt64).Sub(-1) }, 0, true},
+		{"sub underflow", func() (Micro, error) { return Micro(math.MinInt64).Sub(1) }, 0, true},
+		{"mul", func() (Micro, error) { return Micro(-3).Mul(MicrosPerUnit) }, -3 * MicrosPerUnit, false},
+		{"mul by zero", func() (Micro, error) { return Micro(math.MaxInt64).Mul(0) }, 0, false},
+		{"mul overflow", func() (Micro, error) { return Micro(math.MaxInt64 / 2).Mul(3) }, 0, true},
+		{"mul min by -1", func() (Micro, error) { return Micro(math.MinInt64).Mul(-1) }, 0, true},
+		{"mul -1 by min", func() (Micro, error) { return Micro(-1).Mul(math.MinInt64) }, 0, true},
+	}
+	for _, test := range tests {
+		got, err := test.op()
+		if got != test.want || (err != nil) != test.wantErr {
+			t.Errorf("%s = %d, %v, want %d, error %t", test.name, got, err, test.want, test.wantErr)
+		}
+		if err != nil && !errors.Is(err, ErrMicroOverflow) {
+			t.Errorf("%s returned %v, want ErrMicroOverflow", test.name, err)
+		}
+	}
+}
@@ -39,6 +39,7 @@ type GetOrganizationsResponse struct {
 	RequestStatus string                  `json:"request_status"`
 	RequestId     string                  `json:"request_id"`
 	Organizations []*OrganizationResponse `json:"organizations"`
+	Paging        Paging                  `json:"paging"`
 }
 
 // OrganizationResponse is the individual organization object in the response for calls to get organizations
@@ -72,27 +73,43 @@ func (org *OrganizationService) Get(ctx context.Context, organizationId string)
 	return nil, fmt.Errorf(`non-success status returned from snapchat api (get organization): %s`, a.RequestStatus)
 }
 
-// List returns all organizations associated with the authenticated user
-func (org *OrganizationService) List(ctx context.Context) ([]*Organization, error) {
-	path := "me/organizations"
-	req, err := org.client.createRequest("GET", path, nil)
+// List returns all organizations associated with the authenticated user, following every page of results
+func (org *OrganizationService) List(ctx context.Context, opts ...func(*ListOptions)) ([]*Organization, error) {
+	var results []*Organization
+	err := collectPages(opts, func(pageOpts []func(*ListOptions)) (string, error) {
+		page, next, err := org.ListPage(ctx, pageOpts...)
+		results = append(results, page...)
+		return next, err
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	if len(results) > 0 {
+		return results, nil
+	}
+	return nil, fmt.Errorf("no organizations found")
+}
+
+// ListPage returns a single page of the organizations associated with the authenticated user, along with
+// the cursor to pass to WithPageCursor to get the next page. The cursor is empty on the last page
+func (org *OrganizationService) ListPage(ctx context.Context, opts ...func(*ListOptions)) ([]*Organization, string, error) {
+	path := "me/organizations"
+	req, err := org.client.createListRequest(path, opts)
+	if err != nil {
+		return nil, "", err
+	}
+
 	c := new(GetOrganizationsResponse)
 	err = org.client.do(ctx, req, c)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	if strings.ToLower(c.RequestStatus) == "success" {
-		if len(c.Organizations) > 0 {
-			return getOrganizationsFromResponse(c.Organizations), nil
-		}
-		return nil, fmt.Errorf("no organizations found")
+		return getOrganizationsFromResponse(c.Organizations), c.Paging.cursor(), nil
 	}
-	return nil, fmt.Errorf(`non-success status returned from snapchat api (list organizations): %s`, c.RequestStatus)
+	return nil, "", fmt.Errorf(`non-success status returned from snapchat api (list organizations): %s`, c.RequestStatus)
 }
 
 // getOrganizationsFromResponse returns the organization objects in an OrganizationResponse object
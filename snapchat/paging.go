@@ -0,0 +1,96 @@
+package snapchat
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	// MinPageLimit is the smallest number of entities the snapchat ads api will return per page
+	MinPageLimit = 50
+	// MaxPageLimit is the largest number of entities the snapchat ads api will return per page
+	MaxPageLimit = 1000
+)
+
+// ListOptions holds the optional parameters used when listing entities
+type ListOptions struct {
+	// Limit is the number of entities to request per page, the api default is used if zero
+	Limit int
+	// Cursor is the position to resume listing from, listing starts at the first page if empty
+	Cursor string
+}
+
+// WithPageLimit sets the number of entities requested per page
+func WithPageLimit(limit int) func(*ListOptions) {
+	return func(opts *ListOptions) {
+		opts.Limit = limit
+	}
+}
+
+// WithPageCursor resumes listing from a cursor returned by a previous page
+func WithPageCursor(cursor string) func(*ListOptions) {
+	return func(opts *ListOptions) {
+		opts.Cursor = cursor
+	}
+}
+
+// Paging is the paging object included in list responses
+type Paging struct {
+	// NextLink is the url of the next page of results, it is empty on the last page
+	NextLink string `json:"next_link"`
+}
+
+// cursor returns the cursor of the next page, or an empty string if there are no more pages
+func (p Paging) cursor() string {
+	if p.NextLink == "" {
+		return ""
+	}
+	next, err := url.Parse(p.NextLink)
+	if err != nil {
+		return ""
+	}
+	return next.Query().Get("cursor")
+}
+
+// createListRequest is used to get an http request object for a single page of a list call
+func (cli *Client) createListRequest(path string, optFns []func(*ListOptions)) (*http.Request, error) {
+	opts := new(ListOptions)
+	for _, fn := range optFns {
+		fn(opts)
+	}
+
+	params := url.Values{}
+	if opts.Limit != 0 {
+		if opts.Limit < MinPageLimit || opts.Limit > MaxPageLimit {
+			return nil, fmt.Errorf(`page limit must be between %d and %d, got %d`, MinPageLimit, MaxPageLimit, opts.Limit)
+		}
+		params.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	if opts.Cursor != "" {
+		params.Set("cursor", opts.Cursor)
+	}
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+	return cli.createRequest("GET", path, nil)
+}
+
+// collectPages calls fetch for every page of a list call until there are no more pages, starting at
+// the cursor in opts if one is set. fetch returns the cursor of the page following the one it requested
+func collectPages(opts []func(*ListOptions), fetch func([]func(*ListOptions)) (string, error)) error {
+	pageOpts := opts
+	cursor := ""
+	for {
+		next, err := fetch(pageOpts)
+		if err != nil {
+			return err
+		}
+		if next == "" || next == cursor {
+			return nil
+		}
+		cursor = next
+		pageOpts = append(opts[:len(opts):len(opts)], WithPageCursor(cursor))
+	}
+}
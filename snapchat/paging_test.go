@@ -0,0 +1,163 @@
+package snapchat
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestPagingCursor(t *testing.T) {
+	tests := []struct {
+		nextLink string
+		want     string
+	}{
+		{"", ""},
+		{"https://adsapi.snapchat.com/v1/adaccounts/a/campaigns?cursor=abc&limit=50", "abc"},
+		{"https://adsapi.snapchat.com/v1/adaccounts/a/campaigns?limit=50", ""},
+		{"://not a url", ""},
+	}
+	for _, test := range tests {
+		if got := (Paging{NextLink: test.nextLink}).cursor(); got != test.want {
+			t.Errorf("cursor of %q = %q, want %q", test.nextLink, got, test.want)
+		}
+	}
+}
+
+func TestCreateListRequest(t *testing.T) {
+	cli, err := NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		opts    []func(*ListOptions)
+		want    string
+		wantErr bool
+	}{
+		{"no options", nil, DefaultSnapchatHost + "/v1/campaigns", false},
+		{"limit and cursor", []func(*ListOptions){WithPageLimit(100), WithPageCursor("abc")}, DefaultSnapchatHost + "/v1/campaigns?cursor=abc&limit=100", false},
+		{"limit too small", []func(*ListOptions){WithPageLimit(MinPageLimit - 1)}, "", true},
+		{"limit too large", []func(*ListOptions){WithPageLimit(MaxPageLimit + 1)}, "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req, err := cli.createListRequest("campaigns", test.opts)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got error %v, want error %t", err, test.wantErr)
+			}
+			if err == nil && req.URL.String() != test.want {
+				t.Errorf("got url %s, want %s", req.URL, test.want)
+			}
+		})
+	}
+}
+
+// newPagingServer returns a client for a server that lists the campaigns of ad account "account" in pages of
+// the given sizes, linking every page to the next one, along with a func returning the cursors requested so far
+func newPagingServer(t *testing.T, pageSizes ...int) (*Client, func() []string) {
+	t.Helper()
+	var mu sync.Mutex
+	var cursors []string
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/adaccounts/account/campaigns" {
+			http.NotFound(w, r)
+			return
+		}
+		cursor := r.URL.Query().Get("cursor")
+		mu.Lock()
+		cursors = append(cursors, cursor)
+		mu.Unlock()
+
+		page, first := 0, 0
+		if cursor != "" {
+			page, _ = strconv.Atoi(cursor)
+		}
+		for i := 0; i < page; i++ {
+			first += pageSizes[i]
+		}
+		response := GetCampaignsResponse{RequestStatus: "SUCCESS"}
+		for i := 0; i < pageSizes[page]; i++ {
+			response.Campaigns = append(response.Campaigns, &CampaignResponse{
+				SubRequestStatus: "SUCCESS",
+				Campaign:         Campaign{Id: strconv.Itoa(first + i)},
+			})
+		}
+		if page+1 < len(pageSizes) {
+			response.Paging.NextLink = server.URL + r.URL.Path + "?cursor=" + strconv.Itoa(page+1)
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+
+	cli, err := NewClient(WithHost(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cli, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), cursors...)
+	}
+}
+
+func campaignIds(campaigns []*Campaign) []string {
+	ids := make([]string, len(campaigns))
+	for i, campaign := range campaigns {
+		ids[i] = campaign.Id
+	}
+	return ids
+}
+
+func TestListFollowsNextLink(t *testing.T) {
+	cli, cursors := newPagingServer(t, 2, 1, 2)
+	campaigns, err := cli.Campaigns.List(context.Background(), "account")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := campaignIds(campaigns), []string{"0", "1", "2", "3", "4"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got campaigns %v, want %v", got, want)
+	}
+	if got, want := cursors(), []string{"", "1", "2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("requested cursors %v, want %v", got, want)
+	}
+}
+
+func TestListPage(t *testing.T) {
+	cli, cursors := newPagingServer(t, 2, 2)
+	campaigns, next, err := cli.Campaigns.ListPage(context.Background(), "account", WithPageCursor("1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := campaignIds(campaigns), []string{"2", "3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got campaigns %v, want %v", got, want)
+	}
+	if next != "" {
+		t.Errorf("got cursor %q on the last page, want none", next)
+	}
+	if got, want := cursors(), []string{"1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("requested cursors %v, want %v", got, want)
+	}
+}
+
+func TestCollectPagesStopsOnRepeatedCursor(t *testing.T) {
+	calls := 0
+	err := collectPages(nil, func([]func(*ListOptions)) (string, error) {
+		calls++
+		if calls > 5 {
+			t.Fatal("collectPages did not stop on a repeated cursor")
+		}
+		return "same", nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("fetched %d pages, want 2", calls)
+	}
+}
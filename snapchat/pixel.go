@@ -0,0 +1,187 @@
+package snapchat
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// PixelService provides functions for interacting with snap pixels
+type PixelService service
+
+// Pixel represents a snap pixel in the snapchat ads api, used to measure events on a website
+type Pixel struct {
+	// Id is the id representing a single pixel
+	Id string `json:"id"`
+	// Name is the name of the pixel
+	Name string `json:"name"`
+	// OrganizationId is the id of the organization the pixel is under
+	OrganizationId string `json:"organization_id"`
+	// Status is the status of the pixel
+	Status string `json:"status"`
+	// EffectiveStatus is the status of the pixel, taking into account the status of its organization
+	EffectiveStatus string `json:"effective_status"`
+	// PixelJavascript is the javascript snippet to install the pixel on a website
+	PixelJavascript string `json:"pixel_javascript"`
+	// CreatedAt is the time when the pixel was created
+	CreatedAt time.Time `json:"created_at"`
+	// UpdatedAt is the time when the pixel was last updated
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// GetPixelsResponse is the response object returned when getting pixels
+type GetPixelsResponse struct {
+	RequestStatus string           `json:"request_status"`
+	RequestId     string           `json:"request_id"`
+	Pixels        []*PixelResponse `json:"pixels"`
+}
+
+// PixelResponse is the object for a single pixel response
+type PixelResponse struct {
+	SubRequestStatus string `json:"sub_request_status"`
+	Pixel            Pixel  `json:"pixel"`
+}
+
+// GetPixelStatsResponse is the response object returned when getting pixel domains or pixel stats
+type GetPixelStatsResponse struct {
+	RequestStatus   string                  `json:"request_status"`
+	RequestId       string                  `json:"request_id"`
+	TimeseriesStats []*PixelTimeseriesStats `json:"timeseries_stats"`
+}
+
+// PixelTimeseriesStats is a wrapper object for the pixel stats response
+type PixelTimeseriesStats struct {
+	SubRequestStatus string              `json:"sub_request_status"`
+	TimeseriesStat   PixelTimeseriesStat `json:"timeseries_stat"`
+}
+
+// PixelTimeseriesStat contains the events fired by a pixel over a time range
+type PixelTimeseriesStat struct {
+	// Id is the id of the pixel
+	Id string `json:"id"`
+	// Type is the type of the entity (PIXEL)
+	Type string `json:"type"`
+	// Granularity is the length of each interval in the timeseries (HOUR, DAY)
+	Granularity string `json:"granularity"`
+	// StartTime is the start of the time range
+	StartTime time.Time `json:"start_time"`
+	// EndTime is the end of the time range
+	EndTime time.Time `json:"end_time"`
+	// Domains is the list of domains the pixel has fired on, only set when getting pixel domains
+	Domains []*PixelDomain `json:"domains"`
+	// Timeseries is the list of intervals in the time range, only set when getting pixel stats
+	Timeseries []*PixelStatsInterval `json:"timeseries"`
+}
+
+// PixelDomain is a domain a pixel has fired on
+type PixelDomain struct {
+	// Domain is the name of the domain
+	Domain string `json:"domain_name"`
+	// TotalEvents is the number of events fired on the domain
+	TotalEvents int64 `json:"total_events"`
+}
+
+// PixelStatsInterval contains the number of events fired by a pixel in a single interval
+type PixelStatsInterval struct {
+	// StartTime is the start of the interval
+	StartTime time.Time `json:"start_time"`
+	// EndTime is the end of the interval
+	EndTime time.Time `json:"end_time"`
+	// Stats maps event types (e.g. PAGE_VIEW, ADD_CART, PURCHASE) to the number of times they were fired
+	Stats map[string]int64 `json:"stats"`
+}
+
+// Totals returns the number of times each event type was fired over the whole time range
+func (stat *PixelTimeseriesStat) Totals() map[string]int64 {
+	totals := make(map[string]int64)
+	for _, interval := range stat.Timeseries {
+		for event, count := range interval.Stats {
+			totals[event] += count
+		}
+	}
+	return totals
+}
+
+// Get retrieves a specific pixel
+func (pxl *PixelService) Get(ctx context.Context, pixelId string) (*Pixel, error) {
+	path := fmt.Sprintf(`pixels/%s`, pixelId)
+	return pxl.get(ctx, path, fmt.Sprintf(`pixel id: %s`, pixelId))
+}
+
+// GetForAdAccount retrieves the pixel associated with the specified ad account
+func (pxl *PixelService) GetForAdAccount(ctx context.Context, adAccountId string) (*Pixel, error) {
+	path := fmt.Sprintf(`adaccounts/%s/pixels`, adAccountId)
+	return pxl.get(ctx, path, fmt.Sprintf(`ad account id: %s`, adAccountId))
+}
+
+// ListDomains retrieves the domains a specific pixel has fired on
+func (pxl *PixelService) ListDomains(ctx context.Context, pixelId string) ([]*PixelDomain, error) {
+	path := fmt.Sprintf(`pixels/%s/domains/stats`, pixelId)
+	stat, err := pxl.getStats(ctx, path, pixelId)
+	if err != nil {
+		return nil, err
+	}
+	return stat.Domains, nil
+}
+
+// GetStats retrieves the events fired by a specific pixel between start and end, in intervals of the given
+// granularity (GranularityHour, GranularityDay)
+func (pxl *PixelService) GetStats(ctx context.Context, pixelId string, start, end time.Time, granularity Granularity) (*PixelTimeseriesStat, error) {
+	if granularity != GranularityHour && granularity != GranularityDay {
+		return nil, fmt.Errorf("pixel stats granularity must be %s or %s, got %q", GranularityHour, GranularityDay, granularity)
+	}
+	params := url.Values{}
+	params.Set("granularity", string(granularity))
+	params.Set("start_time", start.Format(time.RFC3339))
+	params.Set("end_time", end.Format(time.RFC3339))
+	path := fmt.Sprintf(`pixels/%s/stats?%s`, pixelId, params.Encode())
+	return pxl.getStats(ctx, path, pixelId)
+}
+
+// get retrieves the first pixel returned from path
+func (pxl *PixelService) get(ctx context.Context, path, description string) (*Pixel, error) {
+	req, err := pxl.client.createRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	p := new(GetPixelsResponse)
+	err = pxl.client.do(ctx, req, p)
+	if err != nil {
+		return nil, err
+	}
+	if strings.ToLower(p.RequestStatus) == "success" {
+		for _, val := range p.Pixels {
+			if strings.ToLower(val.SubRequestStatus) == "success" {
+				return &val.Pixel, nil
+			}
+		}
+		return nil, fmt.Errorf("no pixels found for %s", description)
+	}
+	return nil, fmt.Errorf(`non-success status returned from snapchat api (get pixel for %s): %s`, description, p.RequestStatus)
+}
+
+// getStats retrieves the first pixel timeseries stat returned from path
+func (pxl *PixelService) getStats(ctx context.Context, path, pixelId string) (*PixelTimeseriesStat, error) {
+	req, err := pxl.client.createRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	p := new(GetPixelStatsResponse)
+	err = pxl.client.do(ctx, req, p)
+	if err != nil {
+		return nil, err
+	}
+	if strings.ToLower(p.RequestStatus) == "success" {
+		for _, val := range p.TimeseriesStats {
+			if strings.ToLower(val.SubRequestStatus) == "success" {
+				return &val.TimeseriesStat, nil
+			}
+		}
+		return nil, fmt.Errorf("no stats found for pixel id: %s", pixelId)
+	}
+	return nil, fmt.Errorf(`non-success status returned from snapchat api (get stats for pixel with id %s): %s`, pixelId, p.RequestStatus)
+}
@@ -6,32 +6,90 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
+	"strings"
+	"time"
 
 	"golang.org/x/net/context/ctxhttp"
 )
 
 // do is used to executed http responses and unmarshal the results into the provided interface
 func (cli *Client) do(ctx context.Context, request *http.Request, target interface{}) error {
+	cli.setHeaders(request)
+
+	policy := cli.retryPolicy
+	if policy == nil || !isIdempotentMethod(request.Method) {
+		_, err := cli.send(ctx, request, target)
+		return err
+	}
+
+	for attempt := 1; ; attempt++ {
+		response, err := cli.send(ctx, request, target)
+		if err == nil {
+			return nil
+		}
+		if response == nil || !isRetryableStatusCode(response.StatusCode) || attempt >= policy.MaxAttempts {
+			if attempt > 1 {
+				return &ErrRetryFailed{Attempts: attempt, Err: err}
+			}
+			return err
+		}
+		if sleepErr := sleep(ctx, policy.delay(attempt, response)); sleepErr != nil {
+			return &ErrRetryFailed{Attempts: attempt, Err: err, ContextErr: sleepErr}
+		}
+		if request.GetBody != nil {
+			body, bodyErr := request.GetBody()
+			if bodyErr != nil {
+				return bodyErr
+			}
+			request.Body = body
+		}
+	}
+}
+
+// doOnce is like do, but the request is never sent again, even if the client has a retry policy. It is used for
+// GET requests that are not idempotent, such as submitting an async report
+func (cli *Client) doOnce(ctx context.Context, request *http.Request, target interface{}) error {
+	cli.setHeaders(request)
+	_, err := cli.send(ctx, request, target)
+	return err
+}
+
+// setHeaders sets the default and custom headers on a request
+func (cli *Client) setHeaders(request *http.Request) {
 	request.Header.Set("User-Agent", `Snapchat Ads API Go SDK `+cli.version)
+	for k, v := range cli.GetCustomHTTPHeaders() {
+		request.Header.Set(k, v)
+	}
+}
 
-	response, err := ctxhttp.Do(ctx, cli.client, request)
+// send performs a single attempt of the request, the response is returned so that its status code
+// and headers can be inspected after the body has been consumed
+func (cli *Client) send(ctx context.Context, request *http.Request, target interface{}) (*http.Response, error) {
+	response, err := ctxhttp.Do(ctx, cli.httpClient(), request)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer response.Body.Close()
 
 	if response != nil {
-		if statusErr := getErrorFromStatusCode(response.StatusCode); statusErr != nil {
-			return statusErr
+		if response.StatusCode < 200 || response.StatusCode >= 400 {
+			return response, newAPIError(response, target)
 		}
-		return json.NewDecoder(response.Body).Decode(target)
+		return response, json.NewDecoder(response.Body).Decode(target)
 	}
-	return fmt.Errorf(`nil response`)
+	return nil, fmt.Errorf(`nil response`)
 }
 
 // createRequest is used to get an http request object
 func (cli *Client) createRequest(method, path string, body interface{}) (*http.Request, error) {
+	return cli.createRequestForURL(method, cli.url(path), body)
+}
+
+// createRequestForURL is used to get an http request object for a full url, which may be on a host other than
+// the ads api host
+func (cli *Client) createRequestForURL(method, url string, body interface{}) (*http.Request, error) {
 	var buf io.ReadWriter
 	if body != nil {
 		buf = new(bytes.Buffer)
@@ -45,8 +103,7 @@ func (cli *Client) createRequest(method, path string, body interface{}) (*http.R
 		body = bytes.NewReader([]byte{})
 	}
 
-	path = fmt.Sprintf(`%s/%s/%s`, cli.host, cli.version, path)
-	request, err := http.NewRequest(method, path, buf)
+	request, err := http.NewRequest(method, url, buf)
 	if err != nil {
 		return nil, err
 	}
@@ -57,6 +114,116 @@ func (cli *Client) createRequest(method, path string, body interface{}) (*http.R
 	return request, nil
 }
 
+// createMultipartRequest is used to get a POST request object with a multipart form body containing the
+// provided fields and, if file is not nil, a file part with the given file name
+func (cli *Client) createMultipartRequest(path string, fields map[string]string, fileName string, file io.Reader) (*http.Request, error) {
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+	for key, value := range fields {
+		if err := writer.WriteField(key, value); err != nil {
+			return nil, err
+		}
+	}
+	if file != nil {
+		part, err := writer.CreateFormFile("file", fileName)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.Copy(part, file); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequest("POST", cli.url(path), buf)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	return request, nil
+}
+
+// url returns the full url of an api path. Paths starting with a slash already include the version,
+// as returned by the api in some responses, and are only prefixed with the host
+func (cli *Client) url(path string) string {
+	if strings.HasPrefix(path, "/") {
+		return cli.host + path
+	}
+	return fmt.Sprintf(`%s/%s/%s`, cli.host, cli.version, path)
+}
+
+// maxErrorBodySize limits how much of an error response body is read
+const maxErrorBodySize = 1 << 20
+
+// APIError is the error returned when the snapchat api responds with a non-success status code.
+// Errors for well known status codes unwrap to the matching error type (ErrBadRequest, ErrNotFound, ...)
+// so that they can be matched using errors.Is and errors.As
+type APIError struct {
+	// StatusCode is the http status code returned by the api
+	StatusCode int `json:"-"`
+	// RequestStatus is the status of the request returned by the api
+	RequestStatus string `json:"request_status"`
+	// RequestId is the id the api associated with the request
+	RequestId string `json:"request_id"`
+	// ErrorCode is the snapchat error code describing the failure
+	ErrorCode string `json:"error_code"`
+	// DebugMessage is a developer facing description of the failure
+	DebugMessage string `json:"debug_message"`
+	// DisplayMessage is a user facing description of the failure
+	DisplayMessage string `json:"display_message"`
+	// Body is the raw response body, it is only set if the body could not be decoded
+	Body string `json:"-"`
+}
+
+func (err *APIError) Error() string {
+	msg := fmt.Sprintf(`%d status code returned from snapchat api`, err.StatusCode)
+	if statusErr := getErrorFromStatusCode(err.StatusCode); statusErr != nil {
+		msg = statusErr.Error()
+	}
+	if err.RequestId != "" {
+		msg += fmt.Sprintf(` (request id: %s)`, err.RequestId)
+	}
+	if err.ErrorCode != "" {
+		msg += fmt.Sprintf(` [%s]`, err.ErrorCode)
+	}
+	switch {
+	case err.DebugMessage != "":
+		msg += ": " + err.DebugMessage
+	case err.DisplayMessage != "":
+		msg += ": " + err.DisplayMessage
+	case err.Body != "":
+		msg += ": " + err.Body
+	}
+	return msg
+}
+
+// Unwrap returns the error type matching the status code, or nil if there is none
+func (err *APIError) Unwrap() error {
+	return getErrorFromStatusCode(err.StatusCode)
+}
+
+// newAPIError builds an APIError from a non-success response, using the error details in the body when present.
+// If target is not nil the body is decoded into it as well, so that the per-entity results of a batch request
+// that failed as a whole are not lost
+func newAPIError(response *http.Response, target interface{}) error {
+	apiErr := &APIError{StatusCode: response.StatusCode}
+	body, err := io.ReadAll(io.LimitReader(response.Body, maxErrorBodySize))
+	if err != nil || len(bytes.TrimSpace(body)) == 0 {
+		return apiErr
+	}
+	if err := json.Unmarshal(body, apiErr); err != nil {
+		apiErr.Body = string(body)
+		return apiErr
+	}
+	if target != nil {
+		_ = json.Unmarshal(body, target)
+	}
+	return apiErr
+}
+
+// getErrorFromStatusCode returns the error type matching a status code, or nil if there is none
 func getErrorFromStatusCode(statusCode int) error {
 	switch statusCode {
 	case 400:
@@ -79,14 +246,108 @@ func getErrorFromStatusCode(statusCode int) error {
 		return new(ErrInternalServerError)
 	case 503:
 		return new(ErrServiceUnavailable)
-	default:
-		if statusCode < 200 || statusCode >= 400 {
-			return fmt.Errorf(`%d status code returned from snapchat api`, statusCode)
-		}
 	}
 	return nil
 }
 
+// SubRequestFailure describes a single entity in a batch request that the api did not process successfully
+type SubRequestFailure struct {
+	// Index is the position of the entity in the batch request
+	Index int
+	// Status is the sub request status returned for the entity
+	Status string
+	// Reason is the reason given by the api for the failure
+	Reason string
+}
+
+// ErrBatchFailure is the error returned when one or more entities in a batch request were not successful.
+// The entities that were processed successfully are still returned alongside this error
+type ErrBatchFailure struct {
+	// Failures holds an entry for every entity that was not successful
+	Failures []*SubRequestFailure
+	// Err is the error of the request as a whole, such as an APIError when the api responded with a non-success
+	// status code along with the per-entity results. It is nil if the request itself succeeded
+	Err error
+}
+
+func (err *ErrBatchFailure) Error() string {
+	reasons := make([]string, len(err.Failures))
+	for i, failure := range err.Failures {
+		reasons[i] = fmt.Sprintf(`item %d: %s`, failure.Index, failure.Status)
+		if failure.Reason != "" {
+			reasons[i] += fmt.Sprintf(` (%s)`, failure.Reason)
+		}
+	}
+	msg := fmt.Sprintf(`%d batch items failed: %s`, len(err.Failures), strings.Join(reasons, "; "))
+	if err.Err != nil {
+		msg += fmt.Sprintf(` (%s)`, err.Err)
+	}
+	return msg
+}
+
+// Unwrap returns the error of the request as a whole, so that an APIError can be matched with errors.Is and errors.As
+func (err *ErrBatchFailure) Unwrap() error {
+	return err.Err
+}
+
+// add records a failed entity in the batch
+func (err *ErrBatchFailure) add(index int, status, reason string) {
+	err.Failures = append(err.Failures, &SubRequestFailure{Index: index, Status: status, Reason: reason})
+}
+
+// errOrNil returns the error if any failures were recorded, and nil otherwise
+func (err *ErrBatchFailure) errOrNil() error {
+	if len(err.Failures) == 0 {
+		return nil
+	}
+	return err
+}
+
+// wrap returns the error wrapping requestErr, the error of the request as a whole, if any failures were
+// recorded, and requestErr otherwise
+func (err *ErrBatchFailure) wrap(requestErr error) error {
+	if len(err.Failures) == 0 {
+		return requestErr
+	}
+	err.Err = requestErr
+	return err
+}
+
+// optionalTime returns nil for a zero time so that it is omitted from request bodies
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// optionalValue returns nil for a zero value so that it is omitted from request bodies, unless the field was
+// cleared with ClearFields, in which case the zero value is sent
+func optionalValue[T comparable](value T, cleared bool) *T {
+	var zero T
+	if value == zero && !cleared {
+		return nil
+	}
+	return &value
+}
+
+// clearedFields returns the fields listed in ClearFields as a set, it returns an error if one of them
+// is not one of the clearable fields
+func clearedFields(fields []string, clearable ...string) (map[string]bool, error) {
+	cleared := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		found := false
+		for _, name := range clearable {
+			found = found || name == field
+		}
+		if !found {
+			return nil, fmt.Errorf("field %s cannot be cleared, clearable fields are: %s", field, strings.Join(clearable, ", "))
+		}
+		cleared[field] = true
+	}
+	return cleared, nil
+}
+
 // ErrUnauthorized is the error returned when the api returns a 401 status code
 type ErrUnauthorized struct{}
 
@@ -94,6 +355,12 @@ func (err *ErrUnauthorized) Error() string {
 	return "401: unauthorized"
 }
 
+// Is reports whether target is also an ErrUnauthorized error
+func (err *ErrUnauthorized) Is(target error) bool {
+	_, ok := target.(*ErrUnauthorized)
+	return ok
+}
+
 // ErrBadRequest is the error returned when the api returns a 400 status code
 type ErrBadRequest struct{}
 
@@ -101,6 +368,12 @@ func (err *ErrBadRequest) Error() string {
 	return "400: bad request"
 }
 
+// Is reports whether target is also an ErrBadRequest error
+func (err *ErrBadRequest) Is(target error) bool {
+	_, ok := target.(*ErrBadRequest)
+	return ok
+}
+
 // ErrForbidden is the error returned when the api returns a 403 status code
 type ErrForbidden struct{}
 
@@ -108,6 +381,12 @@ func (err *ErrForbidden) Error() string {
 	return "403: forbidden"
 }
 
+// Is reports whether target is also an ErrForbidden error
+func (err *ErrForbidden) Is(target error) bool {
+	_, ok := target.(*ErrForbidden)
+	return ok
+}
+
 // ErrNotFound is the error returned when the api returns a 404 status code
 type ErrNotFound struct{}
 
@@ -115,6 +394,12 @@ func (err *ErrNotFound) Error() string {
 	return "404: not found"
 }
 
+// Is reports whether target is also an ErrNotFound error
+func (err *ErrNotFound) Is(target error) bool {
+	_, ok := target.(*ErrNotFound)
+	return ok
+}
+
 // ErrMethodNotAllowed is the error returned when the api returns a 405 status code
 type ErrMethodNotAllowed struct{}
 
@@ -122,6 +407,12 @@ func (err *ErrMethodNotAllowed) Error() string {
 	return "405: method not allowed"
 }
 
+// Is reports whether target is also an ErrMethodNotAllowed error
+func (err *ErrMethodNotAllowed) Is(target error) bool {
+	_, ok := target.(*ErrMethodNotAllowed)
+	return ok
+}
+
 // ErrNotAcceptable is the error returned when the api returns a 406 status code
 type ErrNotAcceptable struct{}
 
@@ -129,6 +420,12 @@ func (err *ErrNotAcceptable) Error() string {
 	return "406: not acceptable"
 }
 
+// Is reports whether target is also an ErrNotAcceptable error
+func (err *ErrNotAcceptable) Is(target error) bool {
+	_, ok := target.(*ErrNotAcceptable)
+	return ok
+}
+
 // ErrGone is the error returned when the api returns a 410 status code
 type ErrGone struct{}
 
@@ -136,6 +433,12 @@ func (err *ErrGone) Error() string {
 	return "410: gone"
 }
 
+// Is reports whether target is also an ErrGone error
+func (err *ErrGone) Is(target error) bool {
+	_, ok := target.(*ErrGone)
+	return ok
+}
+
 // ErrTooManyRequests is the error returned when the api returns a 429 status code
 type ErrTooManyRequests struct{}
 
@@ -143,6 +446,12 @@ func (err *ErrTooManyRequests) Error() string {
 	return "429: too many requests"
 }
 
+// Is reports whether target is also an ErrTooManyRequests error
+func (err *ErrTooManyRequests) Is(target error) bool {
+	_, ok := target.(*ErrTooManyRequests)
+	return ok
+}
+
 // ErrInternalServerError is the error returned when the api returns a 500 status code
 type ErrInternalServerError struct{}
 
@@ -150,9 +459,21 @@ func (err *ErrInternalServerError) Error() string {
 	return "500: internal server error"
 }
 
+// Is reports whether target is also an ErrInternalServerError error
+func (err *ErrInternalServerError) Is(target error) bool {
+	_, ok := target.(*ErrInternalServerError)
+	return ok
+}
+
 // ErrServiceUnavailable is the error returned when the api returns a 503 status code
 type ErrServiceUnavailable struct{}
 
 func (err *ErrServiceUnavailable) Error() string {
 	return "503: service unavailable"
 }
+
+// Is reports whether target is also an ErrServiceUnavailable error
+func (err *ErrServiceUnavailable) Is(target error) bool {
+	_, ok := target.(*ErrServiceUnavailable)
+	return ok
+}
@@ -0,0 +1,93 @@
+package snapchat
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestNewAPIError(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		want       APIError
+		wantMsg    string
+	}{
+		{
+			name:       "error details",
+			statusCode: 400,
+			body:       `{"request_status":"ERROR","request_id":"req","error_code":"E1001","debug_message":"bad name","display_message":"Bad name"}`,
+			want:       APIError{StatusCode: 400, RequestStatus: "ERROR", RequestId: "req", ErrorCode: "E1001", DebugMessage: "bad name", DisplayMessage: "Bad name"},
+			wantMsg:    "400: bad request (request id: req) [E1001]: bad name",
+		},
+		{
+			name:       "display message only",
+			statusCode: 403,
+			body:       `{"request_status":"ERROR","display_message":"not allowed"}`,
+			want:       APIError{StatusCode: 403, RequestStatus: "ERROR", DisplayMessage: "not allowed"},
+			wantMsg:    "403: forbidden: not allowed",
+		},
+		{
+			name:       "body that is not json",
+			statusCode: 502,
+			body:       "bad gateway",
+			want:       APIError{StatusCode: 502, Body: "bad gateway"},
+			wantMsg:    "502 status code returned from snapchat api: bad gateway",
+		},
+		{
+			name:       "empty body",
+			statusCode: 404,
+			want:       APIError{StatusCode: 404},
+			wantMsg:    "404: not found",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := newAPIError(&http.Response{StatusCode: test.statusCode, Body: io.NopCloser(strings.NewReader(test.body))}, nil)
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("got %T, want an *APIError", err)
+			}
+			if *apiErr != test.want {
+				t.Errorf("got %+v, want %+v", *apiErr, test.want)
+			}
+			if err.Error() != test.wantMsg {
+				t.Errorf("got message %q, want %q", err.Error(), test.wantMsg)
+			}
+		})
+	}
+}
+
+func TestAPIErrorIs(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		want       error
+	}{
+		{400, new(ErrBadRequest)},
+		{401, new(ErrUnauthorized)},
+		{403, new(ErrForbidden)},
+		{404, new(ErrNotFound)},
+		{405, new(ErrMethodNotAllowed)},
+		{406, new(ErrNotAcceptable)},
+		{410, new(ErrGone)},
+		{429, new(ErrTooManyRequests)},
+		{500, new(ErrInternalServerError)},
+		{503, new(ErrServiceUnavailable)},
+	}
+	for _, test := range tests {
+		err := error(&APIError{StatusCode: test.statusCode})
+		if !errors.Is(err, test.want) {
+			t.Errorf("%d: errors.Is(err, %T) = false, want true", test.statusCode, test.want)
+		}
+		if errors.Is(err, new(ErrGone)) != (test.statusCode == 410) {
+			t.Errorf("%d: errors.Is(err, *ErrGone) matched the wrong status code", test.statusCode)
+		}
+	}
+
+	if errors.Unwrap(&APIError{StatusCode: 418}) != nil {
+		t.Error("an unknown status code should not unwrap to an error type")
+	}
+}
@@ -0,0 +1,170 @@
+package snapchat
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy describes how requests that fail with a 429 or 5xx status code are retried
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times a request is sent, including the first attempt
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry, it doubles with every following attempt
+	BaseDelay time.Duration
+	// MaxDelay caps the delay computed from BaseDelay, as well as the delay asked for with a Retry-After header
+	MaxDelay time.Duration
+	// Jitter picks a random delay between zero and the computed delay to spread out retries
+	Jitter bool
+	// IgnoreRetryAfter disables using the Retry-After header returned by the api as the delay
+	IgnoreRetryAfter bool
+}
+
+const (
+	// defaultPollInterval is the first delay between polls of an entity the api is processing if no interval is given
+	defaultPollInterval = time.Second
+	// maxPollInterval caps the delay between polls of an entity the api is processing
+	maxPollInterval = 30 * time.Second
+)
+
+// DefaultRetryPolicy returns a retry policy with reasonable defaults for the snapchat ads api
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 4,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+		Jitter:      true,
+	}
+}
+
+// ErrRetryFailed is the error returned when a request that was retried still did not succeed
+type ErrRetryFailed struct {
+	// Attempts is the number of times the request was sent
+	Attempts int
+	// Err is the error returned by the final attempt
+	Err error
+	// ContextErr is the context error that stopped the retries, such as context.DeadlineExceeded when the
+	// deadline would pass before the next attempt. It is nil if the retries ran out
+	ContextErr error
+}
+
+func (err *ErrRetryFailed) Error() string {
+	if err.ContextErr != nil {
+		return fmt.Sprintf("request failed after %d attempts: %s (retries stopped: %s)", err.Attempts, err.Err, err.ContextErr)
+	}
+	return fmt.Sprintf("request failed after %d attempts: %s", err.Attempts, err.Err)
+}
+
+// Unwrap returns the error returned by the final attempt along with the context error that stopped the
+// retries, so that both can be matched with errors.Is and errors.As
+func (err *ErrRetryFailed) Unwrap() []error {
+	if err.ContextErr != nil {
+		return []error{err.Err, err.ContextErr}
+	}
+	return []error{err.Err}
+}
+
+// delay returns how long to wait before sending the next attempt
+func (policy *RetryPolicy) delay(attempt int, response *http.Response) time.Duration {
+	if !policy.IgnoreRetryAfter && response != nil {
+		if retryAfter, ok := parseRetryAfter(response.Header.Get("Retry-After")); ok {
+			if policy.MaxDelay > 0 && retryAfter > policy.MaxDelay {
+				return policy.MaxDelay
+			}
+			return retryAfter
+		}
+	}
+
+	delay := policy.BaseDelay
+	for i := 1; i < attempt && delay > 0; i++ {
+		if policy.MaxDelay > 0 && delay >= policy.MaxDelay {
+			break
+		}
+		if delay > math.MaxInt64/2 {
+			delay = math.MaxInt64
+			break
+		}
+		delay *= 2
+	}
+	if policy.MaxDelay > 0 && delay > policy.MaxDelay {
+		delay = policy.MaxDelay
+	}
+	if policy.Jitter && delay > 0 {
+		delay = time.Duration(rand.Int63n(int64(delay) + 1))
+	}
+	return delay
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a number of seconds or an http date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
+
+// isRetryableStatusCode returns true if a request that failed with the status code may succeed if sent again
+func isRetryableStatusCode(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= 500
+}
+
+// isIdempotentMethod returns true if sending a request with the method more than once is safe
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	}
+	return false
+}
+
+// sleep waits for the given duration, it returns an error without waiting if the context
+// deadline would pass first, or as soon as the context is done
+func sleep(ctx context.Context, d time.Duration) error {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < d {
+		return context.DeadlineExceeded
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// poll calls check until it reports done or returns an error, which poll returns. The delay between calls starts at
+// interval, or one second if interval is not positive, and doubles with every call up to 30 seconds. It returns
+// the context error once the context is done
+func poll(ctx context.Context, interval time.Duration, check func() (done bool, err error)) error {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	policy := RetryPolicy{BaseDelay: interval, MaxDelay: maxPollInterval}
+	for attempt := 1; ; attempt++ {
+		done, err := check()
+		if done || err != nil {
+			return err
+		}
+		if err := sleep(ctx, policy.delay(attempt, nil)); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package snapchat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyDelay(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     RetryPolicy
+		attempt    int
+		retryAfter string
+		want       time.Duration
+	}{
+		{"first attempt", RetryPolicy{BaseDelay: time.Second}, 1, "", time.Second},
+		{"doubles", RetryPolicy{BaseDelay: time.Second}, 3, "", 4 * time.Second},
+		{"capped", RetryPolicy{BaseDelay: time.Second, MaxDelay: 5 * time.Second}, 4, "", 5 * time.Second},
+		{"no overflow", RetryPolicy{BaseDelay: time.Second}, 100, "", time.Duration(1<<63 - 1)},
+		{"zero base delay", RetryPolicy{}, 3, "", 0},
+		{"retry after seconds", RetryPolicy{BaseDelay: time.Second}, 1, "7", 7 * time.Second},
+		{"retry after capped", RetryPolicy{BaseDelay: time.Second, MaxDelay: 30 * time.Second}, 1, "86400", 30 * time.Second},
+		{"retry after ignored", RetryPolicy{BaseDelay: time.Second, IgnoreRetryAfter: true}, 1, "7", time.Second},
+		{"invalid retry after", RetryPolicy{BaseDelay: time.Second}, 2, "soon", 2 * time.Second},
+		{"negative retry after", RetryPolicy{BaseDelay: time.Second}, 1, "-1", time.Second},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			response := &http.Response{Header: http.Header{}}
+			if test.retryAfter != "" {
+				response.Header.Set("Retry-After", test.retryAfter)
+			}
+			if got := test.policy.delay(test.attempt, response); got != test.want {
+				t.Errorf("delay(%d) = %s, want %s", test.attempt, got, test.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyDelayJitter(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: time.Second, MaxDelay: 4 * time.Second, Jitter: true}
+	for attempt := 1; attempt <= 5; attempt++ {
+		max := policy
+		max.Jitter = false
+		limit := max.delay(attempt, nil)
+		for i := 0; i < 100; i++ {
+			if got := policy.delay(attempt, nil); got < 0 || got > limit {
+				t.Fatalf("delay(%d) = %s, want between 0 and %s", attempt, got, limit)
+			}
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	date := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	past := time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)
+	tests := []struct {
+		value  string
+		min    time.Duration
+		max    time.Duration
+		wantOk bool
+	}{
+		{"", 0, 0, false},
+		{"0", 0, 0, true},
+		{"120", 2 * time.Minute, 2 * time.Minute, true},
+		{"-5", 0, 0, false},
+		{"1.5", 0, 0, false},
+		{date, 59 * time.Minute, time.Hour, true},
+		{past, 0, 0, true},
+	}
+	for _, test := range tests {
+		got, ok := parseRetryAfter(test.value)
+		if ok != test.wantOk || got < test.min || got > test.max {
+			t.Errorf("parseRetryAfter(%q) = %s, %t, want between %s and %s, %t", test.value, got, ok, test.min, test.max, test.wantOk)
+		}
+	}
+}
+
+// newRetryTestClient returns a client whose requests are answered by the handler, with a retry policy that does not wait
+func newRetryTestClient(t *testing.T, attempts int, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	cli, err := NewClient(WithHost(server.URL), WithRetryPolicy(RetryPolicy{MaxAttempts: attempts}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cli
+}
+
+func TestDoRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		statuses []int
+		wantSent int32
+		wantErr  error
+	}{
+		{"success", "GET", []int{200}, 1, nil},
+		{"retried until success", "GET", []int{503, 429, 200}, 3, nil},
+		{"retries run out", "GET", []int{503, 503, 503, 503}, 3, new(ErrServiceUnavailable)},
+		{"not retryable", "GET", []int{404, 200}, 1, new(ErrNotFound)},
+		{"post not retried", "POST", []int{503, 200}, 1, new(ErrServiceUnavailable)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var sent int32
+			cli := newRetryTestClient(t, 3, func(w http.ResponseWriter, r *http.Request) {
+				status := test.statuses[atomic.AddInt32(&sent, 1)-1]
+				w.WriteHeader(status)
+				fmt.Fprint(w, `{"request_status":"SUCCESS"}`)
+			})
+			req, err := cli.createRequest(test.method, "me", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = cli.do(context.Background(), req, new(getAuthenticatedUserResponse))
+			if sent != test.wantSent {
+				t.Errorf("sent %d requests, want %d", sent, test.wantSent)
+			}
+			if test.wantErr == nil && err != nil || test.wantErr != nil && !errors.Is(err, test.wantErr) {
+				t.Errorf("got error %v, want %v", err, test.wantErr)
+			}
+			var retryErr *ErrRetryFailed
+			if errors.As(err, &retryErr) != (test.wantSent > 1 && test.wantErr != nil) {
+				t.Errorf("got error %v, want an ErrRetryFailed only when retries ran out", err)
+			}
+		})
+	}
+}
+
+func TestDoRetriesStopAtDeadline(t *testing.T) {
+	cli := newRetryTestClient(t, 3, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	cli.retryPolicy.MaxDelay = time.Hour
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	req, err := cli.createRequest("GET", "me", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = cli.do(ctx, req, new(getAuthenticatedUserResponse))
+	if !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, new(ErrTooManyRequests)) {
+		t.Errorf("got error %v, want both the deadline and the last api error", err)
+	}
+}
+
+func TestPoll(t *testing.T) {
+	failure := errors.New("failed")
+	tests := []struct {
+		name      string
+		interval  time.Duration
+		results   []error
+		doneAfter int
+		timeout   time.Duration
+		wantCalls int
+		wantErr   error
+	}{
+		{"done", time.Millisecond, nil, 3, time.Second, 3, nil},
+		{"check error", time.Millisecond, []error{nil, failure}, 0, time.Second, 2, failure},
+		{"zero interval waits the default", 0, nil, 0, 100 * time.Millisecond, 1, context.DeadlineExceeded},
+		{"negative interval waits the default", -time.Second, nil, 0, 100 * time.Millisecond, 1, context.DeadlineExceeded},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), test.timeout)
+			defer cancel()
+			calls := 0
+			err := poll(ctx, test.interval, func() (bool, error) {
+				calls++
+				if calls <= len(test.results) && test.results[calls-1] != nil {
+					return false, test.results[calls-1]
+				}
+				return calls == test.doneAfter, nil
+			})
+			if calls != test.wantCalls {
+				t.Errorf("check called %d times, want %d", calls, test.wantCalls)
+			}
+			if !errors.Is(err, test.wantErr) || (err == nil) != (test.wantErr == nil) {
+				t.Errorf("got error %v, want %v", err, test.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,498 @@
+package snapchat
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaxSegmentUsersPerRequest is the largest number of users the api accepts in a single segment users request
+const MaxSegmentUsersPerRequest = 100000
+
+// IdentifierSchema is the type of identifier used to add or remove users from a segment
+type IdentifierSchema string
+
+const (
+	// SchemaEmail identifies users by the SHA-256 hash of their email address
+	SchemaEmail IdentifierSchema = "EMAIL_SHA256"
+	// SchemaPhone identifies users by the SHA-256 hash of their phone number
+	SchemaPhone IdentifierSchema = "PHONE_SHA256"
+	// SchemaMobileAdId identifies users by the SHA-256 hash of their mobile advertising id (IDFA or AAID)
+	SchemaMobileAdId IdentifierSchema = "MOBILE_AD_ID_SHA256"
+)
+
+// LookalikeType is the balance between similarity to the seed segment and reach of a lookalike segment
+type LookalikeType string
+
+const (
+	// LookalikeSimilarity finds the users most similar to the seed segment
+	LookalikeSimilarity LookalikeType = "SIMILARITY"
+	// LookalikeBalance balances similarity to the seed segment with reach
+	LookalikeBalance LookalikeType = "BALANCE"
+	// LookalikeReach finds the largest audience that is similar to the seed segment
+	LookalikeReach LookalikeType = "REACH"
+)
+
+// SegmentService provides functions for interacting with snapchat audience segments
+type SegmentService service
+
+// Segment represents an audience segment in the snapchat ads api
+type Segment struct {
+	// Id is the id representing a single segment
+	Id string `json:"id"`
+	// AdAccountId is the id of the ad account the segment is under
+	AdAccountId string `json:"ad_account_id"`
+	// OrganizationId is the id of the organization the segment is under
+	OrganizationId string `json:"organization_id"`
+	// Name is the name of the segment
+	Name string `json:"name"`
+	// Description is the description of the segment
+	Description string `json:"description"`
+	// Status is the status of the segment
+	Status string `json:"status"`
+	// SourceType is the source of the segment's users (FIRST_PARTY, LOOKALIKE)
+	SourceType string `json:"source_type"`
+	// RetentionInDays is the number of days users are kept in the segment
+	RetentionInDays int `json:"retention_in_days"`
+	// ApproximateNumberUsers is the approximate number of users matched in the segment
+	ApproximateNumberUsers int64 `json:"approximate_number_users"`
+	// UploadStatus is the status of the latest user upload (NO_UPLOAD, PENDING, COMPLETE)
+	UploadStatus string `json:"upload_status"`
+	// TargetableStatus is whether the segment can be used for targeting (NOT_READY, READY, TOO_FEW_USERS)
+	TargetableStatus string `json:"targetable_status"`
+	// VisibleTo is a list of ids of the ad accounts and organizations the segment is shared with
+	VisibleTo []string `json:"visible_to"`
+	// LookalikeSpec describes how a LOOKALIKE segment is built from its seed segment
+	LookalikeSpec *LookalikeSpec `json:"lookalike_spec"`
+	// CreatedAt is the time when the segment was created
+	CreatedAt time.Time `json:"created_at"`
+	// UpdatedAt is the time when the segment was last updated
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// LookalikeSpec describes how a lookalike segment is built from an existing segment
+type LookalikeSpec struct {
+	// SeedSegmentId is the id of the segment whose users the lookalike segment resembles
+	SeedSegmentId string `json:"seed_segment_id"`
+	// Country is the two letter code of the country the lookalike users are found in
+	Country string `json:"country"`
+	// Type is the balance between similarity to the seed segment and reach
+	Type LookalikeType `json:"type"`
+}
+
+// GetSegmentsResponse is the response object returned when getting segments
+type GetSegmentsResponse struct {
+	RequestStatus string             `json:"request_status"`
+	RequestId     string             `json:"request_id"`
+	Segments      []*SegmentResponse `json:"segments"`
+	Paging        Paging             `json:"paging"`
+}
+
+// SegmentResponse is the object for a single segment response
+type SegmentResponse struct {
+	SubRequestStatus      string  `json:"sub_request_status"`
+	SubRequestErrorReason string  `json:"sub_request_error_reason"`
+	Segment               Segment `json:"segment"`
+}
+
+// segmentsRequest is the request object used when creating or updating segments
+type segmentsRequest struct {
+	Segments []*segmentRequest `json:"segments"`
+}
+
+// segmentRequest contains the segment fields that can be sent when creating or updating a segment
+type segmentRequest struct {
+	Id              string         `json:"id,omitempty"`
+	AdAccountId     string         `json:"ad_account_id"`
+	Name            string         `json:"name"`
+	Description     string         `json:"description,omitempty"`
+	SourceType      string         `json:"source_type,omitempty"`
+	RetentionInDays int            `json:"retention_in_days,omitempty"`
+	LookalikeSpec   *LookalikeSpec `json:"lookalike_spec,omitempty"`
+}
+
+// segmentUsersRequest is the request object used when adding or removing segment users
+type segmentUsersRequest struct {
+	Users []*segmentUsers `json:"users"`
+}
+
+// segmentUsers is a list of hashed user identifiers of a single schema
+type segmentUsers struct {
+	Schema []IdentifierSchema `json:"schema"`
+	Data   [][]string         `json:"data"`
+}
+
+// segmentUsersResponse is the response object returned when adding or removing segment users
+type segmentUsersResponse struct {
+	RequestStatus string `json:"request_status"`
+	RequestId     string `json:"request_id"`
+	Users         []*struct {
+		SubRequestStatus      string `json:"sub_request_status"`
+		SubRequestErrorReason string `json:"sub_request_error_reason"`
+		User                  struct {
+			NumberUploadedUsers int64 `json:"number_uploaded_users"`
+		} `json:"user"`
+	} `json:"users"`
+}
+
+// Get retrieves a specific segment
+func (seg *SegmentService) Get(ctx context.Context, segmentId string) (*Segment, error) {
+	path := fmt.Sprintf(`segments/%s`, segmentId)
+	req, err := seg.client.createRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	s := new(GetSegmentsResponse)
+	err = seg.client.do(ctx, req, s)
+	if err != nil {
+		return nil, err
+	}
+	if strings.ToLower(s.RequestStatus) == "success" {
+		if len(s.Segments) > 0 {
+			return &s.Segments[0].Segment, nil
+		}
+		return nil, fmt.Errorf("no segments found with segment id: %s", segmentId)
+	}
+	return nil, fmt.Errorf(`non-success status returned from snapchat api (get segment with id %s): %s`, segmentId, s.RequestStatus)
+}
+
+// List retrieves all segments within a specified ad account, following every page of results
+func (seg *SegmentService) List(ctx context.Context, adAccountId string, opts ...func(*ListOptions)) ([]*Segment, error) {
+	var results []*Segment
+	err := collectPages(opts, func(pageOpts []func(*ListOptions)) (string, error) {
+		page, next, err := seg.ListPage(ctx, adAccountId, pageOpts...)
+		results = append(results, page...)
+		return next, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) > 0 {
+		return results, nil
+	}
+	return nil, fmt.Errorf("no segments found for ad account id: %s", adAccountId)
+}
+
+// ListPage returns a single page of the segments within a specified ad account, along with
+// the cursor to pass to WithPageCursor to get the next page. The cursor is empty on the last page
+func (seg *SegmentService) ListPage(ctx context.Context, adAccountId string, opts ...func(*ListOptions)) ([]*Segment, string, error) {
+	path := fmt.Sprintf(`adaccounts/%s/segments`, adAccountId)
+	req, err := seg.client.createListRequest(path, opts)
+	if err != nil {
+		return nil, "", err
+	}
+
+	s := new(GetSegmentsResponse)
+	err = seg.client.do(ctx, req, s)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if strings.ToLower(s.RequestStatus) == "success" {
+		return getSegmentsFromResponse(s.Segments), s.Paging.cursor(), nil
+	}
+	return nil, "", fmt.Errorf(`non-success status returned from snapchat api (list segments for ad account with id %s): %s`, adAccountId, s.RequestStatus)
+}
+
+// Create creates the provided segments within the specified ad account and returns them as created by the api,
+// including the ids assigned to them. The source type defaults to FIRST_PARTY if it is not set.
+// If some of the segments could not be created the others are still returned along with an ErrBatchFailure
+func (seg *SegmentService) Create(ctx context.Context, adAccountId string, segments ...*Segment) ([]*Segment, error) {
+	body := new(segmentsRequest)
+	for _, segment := range segments {
+		s := newSegmentRequest(adAccountId, segment)
+		s.Id = ""
+		if s.SourceType == "" {
+			s.SourceType = "FIRST_PARTY"
+		}
+		body.Segments = append(body.Segments, s)
+	}
+	return seg.save(ctx, "POST", adAccountId, body)
+}
+
+// Update updates the provided segments within the specified ad account and returns them as updated by the api.
+// If some of the segments could not be updated the others are still returned along with an ErrBatchFailure
+func (seg *SegmentService) Update(ctx context.Context, adAccountId string, segments ...*Segment) ([]*Segment, error) {
+	body := new(segmentsRequest)
+	for i, segment := range segments {
+		if segment.Id == "" {
+			return nil, fmt.Errorf("segment at index %d has no id", i)
+		}
+		body.Segments = append(body.Segments, newSegmentRequest(adAccountId, segment))
+	}
+	return seg.save(ctx, "PUT", adAccountId, body)
+}
+
+// CreateLookalike creates a lookalike segment built from the seed segment in spec within the specified ad account.
+// The returned segment is not targetable until the api has built it, see WaitUntilTargetable
+func (seg *SegmentService) CreateLookalike(ctx context.Context, adAccountId, name string, spec LookalikeSpec) (*Segment, error) {
+	if spec.SeedSegmentId == "" {
+		return nil, fmt.Errorf("lookalike spec has no seed segment id")
+	}
+	segments, err := seg.Create(ctx, adAccountId, &Segment{
+		Name:          name,
+		SourceType:    "LOOKALIKE",
+		LookalikeSpec: &spec,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(segments) > 0 {
+		return segments[0], nil
+	}
+	return nil, fmt.Errorf("no segments returned when creating lookalike of segment with id: %s", spec.SeedSegmentId)
+}
+
+// WaitUntilTargetable polls a specific segment until its targetable status is READY and returns it. The delay
+// between polls starts at interval, or one second if interval is not positive, and doubles with every poll up to
+// 30 seconds. It returns an error along with the segment as of the latest poll if the segment has too few users
+// to be targeted, or once the context is done
+func (seg *SegmentService) WaitUntilTargetable(ctx context.Context, segmentId string, interval time.Duration) (*Segment, error) {
+	var s *Segment
+	err := poll(ctx, interval, func() (bool, error) {
+		current, err := seg.Get(ctx, segmentId)
+		if err != nil {
+			return false, err
+		}
+		s = current
+		if s.TargetableStatus == "TOO_FEW_USERS" {
+			return false, fmt.Errorf("segment with id %s has too few users to be targeted", segmentId)
+		}
+		return s.TargetableStatus == "READY", nil
+	})
+	return s, err
+}
+
+// Delete deletes a specific segment
+func (seg *SegmentService) Delete(ctx context.Context, segmentId string) error {
+	path := fmt.Sprintf(`segments/%s`, segmentId)
+	req, err := seg.client.createRequest("DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	s := new(GetSegmentsResponse)
+	err = seg.client.do(ctx, req, s)
+	if err != nil {
+		return err
+	}
+
+	if strings.ToLower(s.RequestStatus) == "success" {
+		return nil
+	}
+	return fmt.Errorf(`non-success status returned from snapchat api (delete segment with id %s): %s`, segmentId, s.RequestStatus)
+}
+
+// AddUsers normalizes and hashes the provided identifiers and adds them to a specific segment, splitting
+// them into as many requests as needed. It returns the number of users the api reported as uploaded
+func (seg *SegmentService) AddUsers(ctx context.Context, segmentId string, schema IdentifierSchema, identifiers []string) (int64, error) {
+	return seg.sendUsers(ctx, "POST", segmentId, schema, identifiers)
+}
+
+// RemoveUsers normalizes and hashes the provided identifiers and removes them from a specific segment,
+// splitting them into as many requests as needed
+func (seg *SegmentService) RemoveUsers(ctx context.Context, segmentId string, schema IdentifierSchema, identifiers []string) error {
+	_, err := seg.sendUsers(ctx, "DELETE", segmentId, schema, identifiers)
+	return err
+}
+
+// RemoveAllUsers removes every user from a specific segment
+func (seg *SegmentService) RemoveAllUsers(ctx context.Context, segmentId string) error {
+	path := fmt.Sprintf(`segments/%s/all_users`, segmentId)
+	req, err := seg.client.createRequest("DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	s := new(GetSegmentsResponse)
+	err = seg.client.do(ctx, req, s)
+	if err != nil {
+		return err
+	}
+
+	if strings.ToLower(s.RequestStatus) == "success" {
+		return nil
+	}
+	return fmt.Errorf(`non-success status returned from snapchat api (remove all users from segment with id %s): %s`, segmentId, s.RequestStatus)
+}
+
+// sendUsers hashes identifiers and sends them to the segment users endpoint in batches
+func (seg *SegmentService) sendUsers(ctx context.Context, method, segmentId string, schema IdentifierSchema, identifiers []string) (int64, error) {
+	if len(identifiers) == 0 {
+		return 0, fmt.Errorf("no identifiers provided")
+	}
+	hashes := make([][]string, len(identifiers))
+	for i, identifier := range identifiers {
+		hash, err := HashIdentifier(schema, identifier)
+		if err != nil {
+			return 0, fmt.Errorf("identifier at index %d: %w", i, err)
+		}
+		hashes[i] = []string{hash}
+	}
+
+	path := fmt.Sprintf(`segments/%s/users`, segmentId)
+	var uploaded int64
+	for start := 0; start < len(hashes); start += MaxSegmentUsersPerRequest {
+		end := start + MaxSegmentUsersPerRequest
+		if end > len(hashes) {
+			end = len(hashes)
+		}
+		body := &segmentUsersRequest{
+			Users: []*segmentUsers{{Schema: []IdentifierSchema{schema}, Data: hashes[start:end]}},
+		}
+		req, err := seg.client.createRequest(method, path, body)
+		if err != nil {
+			return uploaded, err
+		}
+
+		u := new(segmentUsersResponse)
+		err = seg.client.do(ctx, req, u)
+		if err != nil {
+			return uploaded, err
+		}
+		if strings.ToLower(u.RequestStatus) != "success" {
+			return uploaded, fmt.Errorf(`non-success status returned from snapchat api (update users of segment with id %s): %s`, segmentId, u.RequestStatus)
+		}
+		for _, val := range u.Users {
+			uploaded += val.User.NumberUploadedUsers
+		}
+	}
+	return uploaded, nil
+}
+
+// save sends a create or update request for segments within an ad account
+func (seg *SegmentService) save(ctx context.Context, method, adAccountId string, body *segmentsRequest) ([]*Segment, error) {
+	if len(body.Segments) == 0 {
+		return nil, fmt.Errorf("no segments provided")
+	}
+
+	path := fmt.Sprintf(`adaccounts/%s/segments`, adAccountId)
+	req, err := seg.client.createRequest(method, path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	s := new(GetSegmentsResponse)
+	err = seg.client.do(ctx, req, s)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(s.Segments) > 0 {
+		return getSegmentsFromBatchResponse(s.Segments)
+	}
+	return nil, fmt.Errorf(`non-success status returned from snapchat api (save segments for ad account with id %s): %s`, adAccountId, s.RequestStatus)
+}
+
+// newSegmentRequest copies the writable fields of a segment into a request object
+func newSegmentRequest(adAccountId string, segment *Segment) *segmentRequest {
+	return &segmentRequest{
+		Id:              segment.Id,
+		AdAccountId:     adAccountId,
+		Name:            segment.Name,
+		Description:     segment.Description,
+		SourceType:      segment.SourceType,
+		RetentionInDays: segment.RetentionInDays,
+		LookalikeSpec:   segment.LookalikeSpec,
+	}
+}
+
+// HashIdentifier normalizes an identifier according to its schema and returns its hex encoded SHA-256 hash
+func HashIdentifier(schema IdentifierSchema, identifier string) (string, error) {
+	var normalized string
+	switch schema {
+	case SchemaEmail:
+		normalized = NormalizeEmail(identifier)
+	case SchemaPhone:
+		var err error
+		if normalized, err = NormalizePhone(identifier); err != nil {
+			return "", err
+		}
+	case SchemaMobileAdId:
+		normalized = NormalizeMobileAdId(identifier)
+	default:
+		return "", fmt.Errorf("unknown identifier schema: %s", schema)
+	}
+	if normalized == "" {
+		return "", fmt.Errorf("empty %s identifier after normalization", schema)
+	}
+	return hashString(normalized), nil
+}
+
+// hashString returns the hex encoded SHA-256 hash of s
+func hashString(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// NormalizeEmail trims surrounding whitespace from an email address and lowercases it
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// NormalizePhone converts a phone number in international format to the E.164 digits expected by the api,
+// removing the leading plus sign or 00 prefix, and any spaces, dashes, dots or brackets. It returns an error
+// if the number does not start with a plus sign or 00 followed by the country code, since a number in national
+// format, such as "(415) 555-0100", would be hashed without its country code and never match
+func NormalizePhone(phone string) (string, error) {
+	number := strings.TrimSpace(phone)
+	switch {
+	case strings.HasPrefix(number, "+"):
+		number = number[1:]
+	case strings.HasPrefix(number, "00"):
+		number = number[2:]
+	default:
+		return "", fmt.Errorf("phone number %q must start with + or 00 followed by the country code", phone)
+	}
+
+	var digits strings.Builder
+	for _, r := range number {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+		default:
+			return "", fmt.Errorf("phone number %q contains invalid character %q", phone, r)
+		}
+	}
+	normalized := digits.String()
+	if strings.HasPrefix(normalized, "0") || len(normalized) < 7 || len(normalized) > 15 {
+		return "", fmt.Errorf("phone number %q is not a valid E.164 number", phone)
+	}
+	return normalized, nil
+}
+
+// NormalizeMobileAdId trims surrounding whitespace from a mobile advertising id and lowercases it
+func NormalizeMobileAdId(mobileAdId string) string {
+	return strings.ToLower(strings.TrimSpace(mobileAdId))
+}
+
+func getSegmentsFromResponse(list []*SegmentResponse) []*Segment {
+	var results []*Segment
+	for _, val := range list {
+		if strings.ToLower(val.SubRequestStatus) == "success" {
+			results = append(results, &val.Segment)
+		}
+	}
+	return results
+}
+
+// getSegmentsFromBatchResponse returns the successful segments in a create or update response, along with
+// an ErrBatchFailure describing any segments that were not successful
+func getSegmentsFromBatchResponse(list []*SegmentResponse) ([]*Segment, error) {
+	var results []*Segment
+	batchErr := new(ErrBatchFailure)
+	for i, val := range list {
+		if strings.ToLower(val.SubRequestStatus) == "success" {
+			results = append(results, &val.Segment)
+			continue
+		}
+		batchErr.add(i, val.SubRequestStatus, val.SubRequestErrorReason)
+	}
+	return results, batchErr.errOrNil()
+}
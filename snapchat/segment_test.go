@@ -0,0 +1,59 @@
+package snapchat
+
+import "testing"
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		phone   string
+		want    string
+		wantErr bool
+	}{
+		{"+14155550100", "14155550100", false},
+		{" +1 (415) 555-0100 ", "14155550100", false},
+		{"0044 20.7946.0000", "442079460000", false},
+		{"(415) 555-0100", "", true},
+		{"4155550100", "", true},
+		{"+1 415 555 0100 ext 2", "", true},
+		{"+0123456789", "", true},
+		{"+12345", "", true},
+		{"+1234567890123456", "", true},
+		{"", "", true},
+	}
+	for _, test := range tests {
+		got, err := NormalizePhone(test.phone)
+		if got != test.want || (err != nil) != test.wantErr {
+			t.Errorf("NormalizePhone(%q) = %q, %v, want %q, error %t", test.phone, got, err, test.want, test.wantErr)
+		}
+	}
+}
+
+func TestNormalizeEmailAndMobileAdId(t *testing.T) {
+	if got := NormalizeEmail("  Test@Example.COM "); got != "test@example.com" {
+		t.Errorf("NormalizeEmail = %q, want test@example.com", got)
+	}
+	if got := NormalizeMobileAdId(" 6D92078A-8246-4BA4-AE5B-76104861E7DC\n"); got != "6d92078a-8246-4ba4-ae5b-76104861e7dc" {
+		t.Errorf("NormalizeMobileAdId = %q, want the lowercased id", got)
+	}
+}
+
+func TestHashIdentifier(t *testing.T) {
+	tests := []struct {
+		schema     IdentifierSchema
+		identifier string
+		want       string
+		wantErr    bool
+	}{
+		{SchemaEmail, " Test@Example.com", "973dfe463ec85785f5f95af5ba3906eedb2d931c24e69824a89ea65dba4e813b", false},
+		{SchemaPhone, "+1 (415) 555-0100", "5e7ec4c79ccac6e420876e65ad0e6b4b2ccf73ec3dccb50297bf2890a1ec73b9", false},
+		{SchemaMobileAdId, "6D92078A-8246-4BA4-AE5B-76104861E7DC", "31b806b4deec8c4cb1ffb18b9ab4ee1fa82a2b30e1f47902f04d03f5db023376", false},
+		{SchemaPhone, "(415) 555-0100", "", true},
+		{SchemaEmail, "   ", "", true},
+		{IdentifierSchema("UNKNOWN"), "id", "", true},
+	}
+	for _, test := range tests {
+		got, err := HashIdentifier(test.schema, test.identifier)
+		if got != test.want || (err != nil) != test.wantErr {
+			t.Errorf("HashIdentifier(%s, %q) = %q, %v, want %q, error %t", test.schema, test.identifier, got, err, test.want, test.wantErr)
+		}
+	}
+}
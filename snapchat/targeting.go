@@ -0,0 +1,86 @@
+package snapchat
+
+// Targeting is the targeting spec of an ad squad, describing the audience its ads are delivered to.
+// Each list of targeting criteria is combined with AND, while the values inside a single criterion are combined with OR
+type Targeting struct {
+	// RegulatedContent must be set to true if the ad squad contains regulated content such as alcohol or gambling
+	RegulatedContent bool `json:"regulated_content,omitempty"`
+	// Geos is the list of geographic targeting criteria
+	Geos []*GeoTargeting `json:"geos,omitempty"`
+	// Demographics is the list of demographic targeting criteria
+	Demographics []*DemographicTargeting `json:"demographics,omitempty"`
+	// Devices is the list of device targeting criteria
+	Devices []*DeviceTargeting `json:"devices,omitempty"`
+	// Interests is the list of interest targeting criteria
+	Interests []*InterestTargeting `json:"interests,omitempty"`
+	// Segments is the list of audience segment targeting criteria
+	Segments []*SegmentTargeting `json:"segments,omitempty"`
+	// EnableTargetingExpansion allows the api to deliver ads beyond the targeting spec when it expects better results
+	EnableTargetingExpansion bool `json:"enable_targeting_expansion,omitempty"`
+}
+
+// GeoTargeting targets a country, optionally narrowed to regions, metros or postal codes within it
+type GeoTargeting struct {
+	// CountryCode is the two letter country code to target (e.g. "us")
+	CountryCode string `json:"country_code"`
+	// RegionIds is a list of region ids within the country
+	RegionIds []string `json:"region_id,omitempty"`
+	// MetroIds is a list of metro (DMA) ids within the country
+	MetroIds []string `json:"metro_id,omitempty"`
+	// PostalCodes is a list of postal codes within the country
+	PostalCodes []string `json:"postal_code,omitempty"`
+	// Operation is either INCLUDE or EXCLUDE
+	Operation string `json:"operation,omitempty"`
+}
+
+// DemographicTargeting targets users by age, gender and language
+type DemographicTargeting struct {
+	// AgeGroups is a list of age groups to target (e.g. "18-20", "21-24")
+	AgeGroups []string `json:"age_groups,omitempty"`
+	// MinAge is the minimum age to target, it cannot be combined with AgeGroups
+	MinAge string `json:"min_age,omitempty"`
+	// MaxAge is the maximum age to target, it cannot be combined with AgeGroups
+	MaxAge string `json:"max_age,omitempty"`
+	// Gender is the gender to target (MALE, FEMALE), all genders are targeted if empty
+	Gender string `json:"gender,omitempty"`
+	// Languages is a list of language codes to target
+	Languages []string `json:"languages,omitempty"`
+	// AdvancedDemographics is a list of advanced demographic ids to target
+	AdvancedDemographics []string `json:"advanced_demographics,omitempty"`
+	// Operation is either INCLUDE or EXCLUDE
+	Operation string `json:"operation,omitempty"`
+}
+
+// DeviceTargeting targets users by their device, operating system and network
+type DeviceTargeting struct {
+	// OsType is the operating system to target (iOS, ANDROID)
+	OsType string `json:"os_type,omitempty"`
+	// OsVersionMin is the minimum operating system version to target
+	OsVersionMin string `json:"os_version_min,omitempty"`
+	// OsVersionMax is the maximum operating system version to target
+	OsVersionMax string `json:"os_version_max,omitempty"`
+	// ConnectionType is the connection type to target (WIFI, CELL)
+	ConnectionType string `json:"connection_type,omitempty"`
+	// CarrierIds is a list of mobile carrier ids to target
+	CarrierIds []string `json:"carrier_id,omitempty"`
+	// MarketingNames is a list of device make and model ids to target
+	MarketingNames []string `json:"marketing_name,omitempty"`
+	// Operation is either INCLUDE or EXCLUDE
+	Operation string `json:"operation,omitempty"`
+}
+
+// InterestTargeting targets users by their interest categories
+type InterestTargeting struct {
+	// CategoryIds is a list of interest category ids (e.g. "SLC_1")
+	CategoryIds []string `json:"category_id"`
+	// Operation is either INCLUDE or EXCLUDE
+	Operation string `json:"operation,omitempty"`
+}
+
+// SegmentTargeting targets users that belong to audience segments
+type SegmentTargeting struct {
+	// SegmentIds is a list of segment ids
+	SegmentIds []string `json:"segment_id"`
+	// Operation is either INCLUDE or EXCLUDE
+	Operation string `json:"operation,omitempty"`
+}
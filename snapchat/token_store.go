@@ -0,0 +1,210 @@
+package snapchat
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// TokenStore stores oauth2 tokens by key, e.g. one key per advertiser, so that tokens refreshed by one client
+// are used by every other client sharing the store. See WithTokenStore
+type TokenStore interface {
+	// Load returns the token stored under key, or nil if there is none
+	Load(ctx context.Context, key string) (*oauth2.Token, error)
+	// Save stores the token under key
+	Save(ctx context.Context, key string, token *oauth2.Token) error
+	// Lock blocks until the caller holds the lock for key or the context is done, and returns the function
+	// that releases the lock. Only one holder of the lock refreshes the token stored under key at a time
+	Lock(ctx context.Context, key string) (unlock func() error, err error)
+}
+
+// WithTokenStore allows the user to share the tokens refreshed by WithOAuth2Config through a token store. The stored
+// token is used as long as it is valid, and the lock for key is held while refreshing it so that concurrent clients
+// never refresh the same token twice. The refresh token passed to WithOAuth2Config is only used while the store
+// has no token under key, and may be empty if it does
+func WithTokenStore(store TokenStore, key string) func(*Client) error {
+	return func(c *Client) error {
+		if store == nil {
+			return fmt.Errorf(`token store must not be nil`)
+		}
+		c.tokenStore = store
+		c.tokenStoreKey = key
+		return nil
+	}
+}
+
+// MemoryTokenStore is a TokenStore that keeps tokens in memory, shared by the clients of a single process
+type MemoryTokenStore struct {
+	mu     sync.Mutex
+	tokens map[string]*oauth2.Token
+	locks  map[string]chan struct{}
+}
+
+// NewMemoryTokenStore creates a new, empty MemoryTokenStore
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{
+		tokens: make(map[string]*oauth2.Token),
+		locks:  make(map[string]chan struct{}),
+	}
+}
+
+// Load returns a copy of the token stored under key, or nil if there is none
+func (store *MemoryTokenStore) Load(ctx context.Context, key string) (*oauth2.Token, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	token, ok := store.tokens[key]
+	if !ok {
+		return nil, nil
+	}
+	copied := *token
+	return &copied, nil
+}
+
+// Save stores a copy of the token under key
+func (store *MemoryTokenStore) Save(ctx context.Context, key string, token *oauth2.Token) error {
+	if token == nil {
+		return fmt.Errorf("cannot save nil token")
+	}
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	copied := *token
+	store.tokens[key] = &copied
+	return nil
+}
+
+// Lock blocks until the caller holds the lock for key or the context is done
+func (store *MemoryTokenStore) Lock(ctx context.Context, key string) (func() error, error) {
+	store.mu.Lock()
+	lock, ok := store.locks[key]
+	if !ok {
+		lock = make(chan struct{}, 1)
+		store.locks[key] = lock
+	}
+	store.mu.Unlock()
+
+	select {
+	case lock <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	var once sync.Once
+	return func() error {
+		once.Do(func() { <-lock })
+		return nil
+	}, nil
+}
+
+// FileTokenStore is a TokenStore that keeps each token in a json file within a directory, shared by every
+// process with access to the directory. Locks are held with an exclusive lock on a lock file next to the token
+// file, which the operating system releases if the process holding it exits, so a lock is never abandoned
+type FileTokenStore struct {
+	// Dir is the directory the token files are kept in
+	Dir string
+	// PollInterval is how often a held lock is checked for release, 100 milliseconds is used if zero
+	PollInterval time.Duration
+}
+
+// NewFileTokenStore creates a new FileTokenStore keeping tokens in dir, creating the directory if needed
+func NewFileTokenStore(dir string) (*FileTokenStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("create token store directory: %w", err)
+	}
+	return &FileTokenStore{Dir: dir}, nil
+}
+
+// Load returns the token stored under key, or nil if there is none
+func (store *FileTokenStore) Load(ctx context.Context, key string) (*oauth2.Token, error) {
+	data, err := os.ReadFile(store.path(key, ".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read token for key %s: %w", key, err)
+	}
+	token := new(oauth2.Token)
+	if err := json.Unmarshal(data, token); err != nil {
+		return nil, fmt.Errorf("decode token for key %s: %w", key, err)
+	}
+	return token, nil
+}
+
+// Save stores the token under key, replacing the token file atomically so that readers never see a partial token
+func (store *FileTokenStore) Save(ctx context.Context, key string, token *oauth2.Token) error {
+	if token == nil {
+		return fmt.Errorf("cannot save nil token")
+	}
+	data, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(store.Dir, ".token-*")
+	if err != nil {
+		return fmt.Errorf("save token for key %s: %w", key, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("save token for key %s: %w", key, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("save token for key %s: %w", key, err)
+	}
+	if err := os.Rename(tmp.Name(), store.path(key, ".json")); err != nil {
+		return fmt.Errorf("save token for key %s: %w", key, err)
+	}
+	return nil
+}
+
+// Lock blocks until the caller holds the lock for key or the context is done. The lock is held until
+// unlock is called, however long that takes
+func (store *FileTokenStore) Lock(ctx context.Context, key string) (func() error, error) {
+	interval := store.PollInterval
+	if interval <= 0 {
+		interval = 100 * time.Millisecond
+	}
+
+	file, err := os.OpenFile(store.path(key, ".lock"), os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("lock token for key %s: %w", key, err)
+	}
+	for {
+		locked, err := tryLockFile(file)
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("lock token for key %s: %w", key, err)
+		}
+		if locked {
+			break
+		}
+		if err := sleep(ctx, interval); err != nil {
+			file.Close()
+			return nil, err
+		}
+	}
+
+	var once sync.Once
+	return func() error {
+		var err error
+		once.Do(func() {
+			err = unlockFile(file)
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+		})
+		return err
+	}, nil
+}
+
+// path returns the path of the file for key with the given extension
+func (store *FileTokenStore) path(key, ext string) string {
+	return filepath.Join(store.Dir, url.PathEscape(key)+ext)
+}
@@ -0,0 +1,198 @@
+package snapchat
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// newTestTokenStores returns a memory store and a file store in a temporary directory, skipping the file store
+// on platforms without file locks
+func newTestTokenStores(t *testing.T) map[string]TokenStore {
+	t.Helper()
+	stores := map[string]TokenStore{"memory": NewMemoryTokenStore()}
+	file, err := NewFileTokenStore(filepath.Join(t.TempDir(), "tokens"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.PollInterval = time.Millisecond
+	unlock, err := file.Lock(context.Background(), "probe")
+	if err != nil {
+		t.Logf("skipping file token store: %v", err)
+		return stores
+	}
+	if err := unlock(); err != nil {
+		t.Fatal(err)
+	}
+	stores["file"] = file
+	return stores
+}
+
+func TestTokenStoreSaveLoad(t *testing.T) {
+	ctx := context.Background()
+	for name, store := range newTestTokenStores(t) {
+		t.Run(name, func(t *testing.T) {
+			token, err := store.Load(ctx, "missing")
+			if err != nil || token != nil {
+				t.Fatalf("Load of a missing key = %v, %v, want nil, nil", token, err)
+			}
+			if err := store.Save(ctx, "key/with spaces", nil); err == nil {
+				t.Error("expected an error when saving a nil token")
+			}
+
+			expiry := time.Now().Add(time.Hour).Round(time.Second)
+			for _, access := range []string{"first", "second"} {
+				saved := &oauth2.Token{AccessToken: access, RefreshToken: "refresh", TokenType: "Bearer", Expiry: expiry}
+				if err := store.Save(ctx, "key/with spaces", saved); err != nil {
+					t.Fatal(err)
+				}
+				loaded, err := store.Load(ctx, "key/with spaces")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if loaded.AccessToken != access || loaded.RefreshToken != "refresh" || !loaded.Expiry.Equal(expiry) {
+					t.Errorf("loaded token %+v, want %+v", loaded, saved)
+				}
+			}
+		})
+	}
+}
+
+func TestTokenStoreLock(t *testing.T) {
+	ctx := context.Background()
+	for name, store := range newTestTokenStores(t) {
+		t.Run(name, func(t *testing.T) {
+			const workers = 10
+			var holders, maxHolders int32
+			var wg sync.WaitGroup
+			for i := 0; i < workers; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					unlock, err := store.Lock(ctx, "key")
+					if err != nil {
+						t.Error(err)
+						return
+					}
+					held := atomic.AddInt32(&holders, 1)
+					for {
+						max := atomic.LoadInt32(&maxHolders)
+						if held <= max || atomic.CompareAndSwapInt32(&maxHolders, max, held) {
+							break
+						}
+					}
+					time.Sleep(time.Millisecond)
+					atomic.AddInt32(&holders, -1)
+					if err := unlock(); err != nil {
+						t.Error(err)
+					}
+				}()
+			}
+			wg.Wait()
+			if maxHolders != 1 {
+				t.Errorf("lock was held by %d callers at once, want 1", maxHolders)
+			}
+		})
+	}
+}
+
+func TestTokenStoreLockWaitsForUnlock(t *testing.T) {
+	for name, store := range newTestTokenStores(t) {
+		t.Run(name, func(t *testing.T) {
+			unlock, err := store.Lock(context.Background(), "key")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			if _, err := store.Lock(ctx, "key"); !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("got error %v while the lock was held, want %v", err, context.DeadlineExceeded)
+			}
+			other, err := store.Lock(ctx, "other key")
+			if err != nil {
+				t.Fatalf("lock of another key: %v", err)
+			}
+			if err := other(); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := unlock(); err != nil {
+				t.Fatal(err)
+			}
+			// unlocking twice must not release a lock taken by someone else in between
+			relock, err := store.Lock(context.Background(), "key")
+			if err != nil {
+				t.Fatalf("lock after unlock: %v", err)
+			}
+			unlock()
+			ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			if _, err := store.Lock(ctx, "key"); !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("got error %v after a second unlock, want the lock to still be held", err)
+			}
+			if err := relock(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestTokenStoreSharedByClientsRefreshesOnce(t *testing.T) {
+	ctx := context.Background()
+	for name, store := range newTestTokenStores(t) {
+		t.Run(name, func(t *testing.T) {
+			endpoint, received := newTokenServer(t, func(int) int { return 3600 })
+			server, recorded := newTestServer(t)
+
+			clients := make([]*Client, 2)
+			for i := range clients {
+				cli, err := NewClient(
+					WithHost(server.URL),
+					WithOAuth2Config(ctx, "client", "secret", "refresh-0"),
+					WithOAuth2Endpoint(endpoint),
+					WithTokenStore(store, "advertiser"),
+				)
+				if err != nil {
+					t.Fatal(err)
+				}
+				clients[i] = cli
+			}
+
+			var wg sync.WaitGroup
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+				go func(cli *Client) {
+					defer wg.Done()
+					if _, err := cli.Users.GetAuthenticatedUser(ctx); err != nil {
+						t.Error(err)
+					}
+				}(clients[i%len(clients)])
+			}
+			wg.Wait()
+
+			if got := received(); len(got) != 1 {
+				t.Errorf("token endpoint was called %d times, want 1", len(got))
+			}
+			tokens, _ := recorded()
+			for i, token := range tokens {
+				if token != "access-1" {
+					t.Errorf("request %d sent access token %q, want access-1", i, token)
+				}
+			}
+			stored, err := store.Load(ctx, "advertiser")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if stored == nil || stored.RefreshToken != "refresh-1" {
+				t.Errorf("stored token %+v, want the rotated refresh token", stored)
+			}
+		})
+	}
+}